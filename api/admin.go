@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var adminMethods = map[string]bool{
+	pb.Feed_DisconnectSubscriber_FullMethodName: true,
+}
+
+// AdminInterceptor lets an admin RPC through only with "authorization: Bearer <token>" metadata,
+// an empty token disables the admin RPCs altogether.
+func AdminInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !adminMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if token == "" {
+			return nil, status.Errorf(codes.PermissionDenied, "%s is disabled, no admin token is configured", info.FullMethod)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get("authorization") {
+			if bearer, ok := strings.CutPrefix(value, "Bearer "); ok && subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1 {
+				return handler(ctx, req)
+			}
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "%s needs the admin token", info.FullMethod)
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	admin := &grpc.UnaryServerInfo{FullMethod: pb.Feed_DisconnectSubscriber_FullMethodName}
+	public := &grpc.UnaryServerInfo{FullMethod: pb.Feed_GetStatus_FullMethodName}
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	cases := []struct {
+		token string
+		ctx   context.Context
+		info  *grpc.UnaryServerInfo
+		code  codes.Code
+	}{
+		{"", context.Background(), public, codes.OK},
+		{"", withToken(""), admin, codes.PermissionDenied},
+		{"secret", context.Background(), admin, codes.Unauthenticated},
+		{"secret", withToken("wrong"), admin, codes.Unauthenticated},
+		{"secret", withToken("secret"), admin, codes.OK},
+	}
+	for i, c := range cases {
+		_, err := AdminInterceptor(c.token)(c.ctx, nil, c.info, handler)
+		if code := status.Code(err); code != c.code {
+			t.Errorf("Case %d: expected %s, got %s", i, c.code, code)
+		}
+	}
+}
@@ -2,39 +2,70 @@ package api
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
 	"github.com/BullionBear/crypto-feed/pkg/service"
 	log "github.com/sirupsen/logrus"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
+// clientNameKey is the gRPC metadata key a client can use to name its subscription.
+const clientNameKey = "client-name"
+
 // server is used to implement feed.FeedServer.
 type feedServer struct {
-	klineSrv *service.KLineService
+	klineMgr   *service.KLineManager
+	tradeMgr   *service.TradeManager      // nil unless trades are enabled
+	bookMgr    *service.OrderBookManager  // nil unless order books are enabled
+	tickerMgr  *service.BookTickerManager // nil unless book tickers are enabled
+	futuresMgr *service.FuturesManager    // USD-M symbols
 	pb.UnimplementedFeedServer
 }
 
-func NewFeedServer(klineSrv *service.KLineService) *feedServer {
+func NewFeedServer(klineMgr *service.KLineManager, tradeMgr *service.TradeManager, bookMgr *service.OrderBookManager, tickerMgr *service.BookTickerManager, futuresMgr *service.FuturesManager) *feedServer {
 	return &feedServer{
-		klineSrv: klineSrv,
+		klineMgr:   klineMgr,
+		tradeMgr:   tradeMgr,
+		bookMgr:    bookMgr,
+		tickerMgr:  tickerMgr,
+		futuresMgr: futuresMgr,
+	}
+}
+
+func (s *feedServer) klineService(symbol string) (*service.KLineService, error) {
+	klineSrv, err := s.klineMgr.Get(symbol)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "symbol %s is not served: %s", symbol, err.Error())
 	}
+	return klineSrv, nil
 }
 
-func (s *feedServer) GetConfig(ctx context.Context, in *emptypb.Empty) (*pb.ConfigResponse, error) {
+func (s *feedServer) GetConfig(ctx context.Context, in *pb.ConfigRequest) (*pb.ConfigResponse, error) {
+	klineSrv, err := s.klineService(in.Symbol)
+	if err != nil {
+		return nil, err
+	}
 	return &pb.ConfigResponse{
-		Symbol: s.klineSrv.Symbol(),
-		Length: s.klineSrv.Length(),
+		Symbol: klineSrv.Symbol(),
+		Length: klineSrv.Length(),
 	}, nil
 }
 
 // GetStatus implements feed.FeedServer
-func (s *feedServer) GetStatus(ctx context.Context, in *emptypb.Empty) (*pb.StatusResponse, error) {
+func (s *feedServer) GetStatus(ctx context.Context, in *pb.StatusRequest) (*pb.StatusResponse, error) {
 	// Example response, normally you would query this data from your application logic
-	log.Info("GetStatus get called")
+	log.Infof("GetStatus get called for %s", in.Symbol)
 	defer log.Info("Leave GetStatus")
-	startKline, err := s.klineSrv.Head()
+	klineSrv, err := s.klineService(in.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	startKline, err := klineSrv.Head()
 	if err != nil {
 		return &pb.StatusResponse{
 			Status:    pb.Status_ERROR,
@@ -44,7 +75,7 @@ func (s *feedServer) GetStatus(ctx context.Context, in *emptypb.Empty) (*pb.Stat
 			Size:      0,
 		}, err
 	}
-	endKline, err := s.klineSrv.Tail()
+	endKline, err := klineSrv.Tail()
 	if err != nil {
 		return &pb.StatusResponse{
 			Status:    pb.Status_ERROR,
@@ -54,58 +85,251 @@ func (s *feedServer) GetStatus(ctx context.Context, in *emptypb.Empty) (*pb.Stat
 			Size:      0,
 		}, err
 	}
-	size := s.klineSrv.Size()
-	status := s.klineSrv.Status()
+	size := klineSrv.Size()
+	srvStatus := klineSrv.Status()
 
 	return &pb.StatusResponse{
-		Status:    convertToStatus(status),
+		Status:    convertToStatus(srvStatus),
 		Start:     startKline.OpenTime,
 		End:       endKline.CloseTime,
 		Timestamp: time.Now().UnixMilli(),
 		Size:      size,
+		Gaps:      klineSrv.Gaps(),
 	}, nil
 }
 
-func (s *feedServer) GetSubscriber(context.Context, *emptypb.Empty) (*pb.SubscriberResponse, error) {
+// Streams a subscriber belongs to, subscriber ids are only unique within one stream
+const (
+	streamKline      = "kline"
+	streamTrade      = "trade"
+	streamOrderBook  = "order_book"
+	streamBookTicker = "book_ticker"
+	streamMarkPrice  = "mark_price"
+)
+
+// subscriberRegistry is a service whose subscribers can be listed and disconnected.
+type subscriberRegistry interface {
+	SubscriberInfos() []service.SubscriberInfo
+	Disconnect(subscriberID int64) error
+}
+
+// subscriberRegistries returns the services serving symbol keyed by stream.
+func (s *feedServer) subscriberRegistries(symbol string) map[string]subscriberRegistry {
+	registries := make(map[string]subscriberRegistry)
+	if klineSrv, err := s.klineService(symbol); err == nil {
+		registries[streamKline] = klineSrv
+	}
+	if tradeSrv, err := s.tradeService(symbol); err == nil {
+		registries[streamTrade] = tradeSrv
+	}
+	if bookSrv, err := s.orderBookService(symbol); err == nil {
+		registries[streamOrderBook] = bookSrv
+	}
+	if tickerSrv, err := s.bookTickerService(symbol); err == nil {
+		registries[streamBookTicker] = tickerSrv
+	}
+	if futuresSrv, err := s.futuresService(symbol); err == nil {
+		registries[streamMarkPrice] = futuresSrv
+	}
+	return registries
+}
+
+func (s *feedServer) GetSubscriber(ctx context.Context, in *pb.SubscriberRequest) (*pb.SubscriberResponse, error) {
+	registries := s.subscriberRegistries(in.Symbol)
+	if len(registries) == 0 {
+		return nil, status.Errorf(codes.NotFound, "symbol %s is not served", in.Symbol)
+	}
+	subscribers := []int64{}
+	if klineSrv, err := s.klineService(in.Symbol); err == nil {
+		subscribers = klineSrv.ListSubsriber()
+	}
+	infos := []*pb.SubscriberInfo{}
+	for _, stream := range []string{streamKline, streamTrade, streamOrderBook, streamBookTicker, streamMarkPrice} {
+		registry, ok := registries[stream]
+		if !ok {
+			continue
+		}
+		for _, info := range registry.SubscriberInfos() {
+			infos = append(infos, &pb.SubscriberInfo{
+				Id:           info.ID,
+				Dropped:      info.Dropped,
+				Peer:         info.Peer,
+				Name:         info.Name,
+				ConnectTime:  info.ConnectTime,
+				Sent:         info.Sent,
+				LastSendTime: info.LastSendTime,
+				QueueDepth:   info.QueueDepth,
+				Stream:       stream,
+			})
+		}
+	}
 	return &pb.SubscriberResponse{
-		Subscribers: s.klineSrv.ListSubsriber(),
+		Subscribers: subscribers,
+		Infos:       infos,
 	}, nil
 }
 
-func (s *feedServer) SubscribeKline(in *emptypb.Empty, stream pb.Feed_SubscribeKlineServer) error {
-	log.Info("SubscribeKline get called")
+func (s *feedServer) DisconnectSubscriber(ctx context.Context, in *pb.DisconnectRequest) (*pb.DisconnectResponse, error) {
+	stream := in.Stream
+	if stream == "" {
+		stream = streamKline
+	}
+	registry, ok := s.subscriberRegistries(in.Symbol)[stream]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "%s stream of %s is not served", stream, in.Symbol)
+	}
+	if err := registry.Disconnect(in.Id); err != nil {
+		return nil, status.Errorf(codes.NotFound, "fail to disconnect %s subscriber %d: %s", stream, in.Id, err.Error())
+	}
+	return &pb.DisconnectResponse{
+		Id: in.Id,
+	}, nil
+}
+
+func (s *feedServer) SubscribeKline(in *pb.SubscribeKlineRequest, stream pb.Feed_SubscribeKlineServer) error {
+	log.Infof("SubscribeKline get called for %s", in.Symbol)
 	defer log.Info("Leave SubscribeKline")
+	klineSrv, err := s.klineService(in.Symbol)
+	if err != nil {
+		return err
+	}
 	klineCh := make(chan *pb.Kline)
-	defer close(klineCh)
+	doneCh := make(chan struct{})
 	kline_handler := func(srvKline *service.Kline) {
 		pbKline := convertToPbKline(srvKline)
-		klineCh <- pbKline
+		select {
+		case klineCh <- pbKline:
+		case <-doneCh:
+		}
 	}
 
-	id := s.klineSrv.Subscribe(kline_handler)
-	defer s.klineSrv.Unsubscribe(id)
-	for kline := range klineCh {
-		response := pb.KlineResponse{
-			Kline: kline,
+	var id int64
+	if in.BarType != pb.BarType_TIME_BAR {
+		if in.Updates || in.FromOpenTime != 0 {
+			return status.Errorf(codes.InvalidArgument, "updates and fromOpenTime only apply to time bars")
+		}
+		barType, err := convertToBarType(in.BarType)
+		if err != nil {
+			return err
 		}
-		if err := stream.Send(&response); err != nil {
-			log.Warnf("Error sending data to client: %s", err.Error())
-			break // Exit the loop if we fail to send data
+		id, err = klineSrv.SubscribeBars(barType, in.Interval, in.Threshold, kline_handler)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "fail to subscribe %s bars: %s", in.BarType, err.Error())
+		}
+	} else if in.Updates {
+		if width, _ := service.IntervalMs(in.Interval); width != 1000 || in.FromOpenTime != 0 {
+			return status.Errorf(codes.InvalidArgument, "intra-bar updates are only served live for 1s klines")
+		}
+		id = klineSrv.SubscribeUpdate(kline_handler)
+	} else if in.FromOpenTime != 0 {
+		id, err = klineSrv.SubscribeFrom(in.FromOpenTime, in.Interval, kline_handler)
+		if errors.Is(err, service.ErrResumeTooOld) {
+			return status.Errorf(codes.OutOfRange, "fail to resume from %d: %s", in.FromOpenTime, err.Error())
+		}
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "fail to resume from %d: %s", in.FromOpenTime, err.Error())
+		}
+	} else {
+		id, err = klineSrv.SubscribeInterval(in.Interval, kline_handler)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "fail to subscribe interval %s: %s", in.Interval, err.Error())
+		}
+	}
+	defer klineSrv.Unsubscribe(id)
+	defer close(doneCh) // Release a handler blocked on klineCh before unsubscribing
+	kickedCh, err := klineSrv.Kicked(id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "fail to watch subscriber %d: %s", id, err.Error())
+	}
+	klineSrv.DescribeSubscriber(id, describeClient(stream.Context()))
+	for {
+		select {
+		case kline := <-klineCh:
+			response := pb.KlineResponse{
+				Kline: kline,
+			}
+			if err := stream.Send(&response); err != nil {
+				log.Warnf("Error sending data to client: %s", err.Error())
+				return nil // Exit the loop if we fail to send data
+			}
+			klineSrv.MarkSent(id)
+		case err := <-kickedCh:
+			return kickedStatus(id, err)
+		case <-stream.Context().Done():
+			return nil
 		}
 	}
-	return nil
 }
 
 func (s *feedServer) ReadHistoricalKline(request *pb.ReadKlineRequest, stream pb.Feed_ReadHistoricalKlineServer) error {
+	klineSrv, err := s.klineService(request.Symbol)
+	if err != nil {
+		return err
+	}
 	start := int64(request.Start) / 1000 * 1000
 	end := int64(request.End) / 1000 * 1000
-	kline_handler := func(srvKline *service.Kline) {
+	var tickerSrv *service.BookTickerService
+	if request.WithSpread {
+		if tickerSrv, err = s.bookTickerService(request.Symbol); err != nil {
+			return err
+		}
+	}
+	var sendErr error
+	kline_handler := func(srvKline *service.Kline) error {
 		pbKline := convertToPbKline(srvKline)
-		stream.Send(&pb.KlineResponse{
+		response := &pb.KlineResponse{
 			Kline: pbKline,
-		})
+		}
+		if tickerSrv != nil {
+			if spread, err := tickerSrv.SpreadAt(srvKline.CloseTime - srvKline.CloseTime%1000); err == nil {
+				response.Spread = convertToPbSpread(&spread)
+			}
+		}
+		sendErr = stream.Send(response)
+		return sendErr
+	}
+	if _, err := service.IntervalMs(request.Interval); err != nil {
+		return status.Errorf(codes.InvalidArgument, "fail to read interval %s: %s", request.Interval, err.Error())
 	}
-	return s.klineSrv.Query(start, end, kline_handler)
+	barType, err := convertToBarType(request.BarType)
+	if err != nil {
+		return err
+	}
+	if err := service.CheckBars(barType, request.Threshold); err != nil {
+		return status.Errorf(codes.InvalidArgument, "fail to build %s bars: %s", request.BarType, err.Error())
+	}
+	if err := klineSrv.QueryBars(start, end, barType, request.Interval, request.Threshold, kline_handler); err != nil {
+		if sendErr != nil {
+			log.Warnf("Error sending data to client: %s", sendErr.Error())
+			return sendErr
+		}
+		return err
+	}
+	return nil
+}
+
+// kickedStatus is the status a stream ends with once the service dropped its subscriber:
+// aborted by an admin, otherwise the client fell behind and exhausted its queue.
+func kickedStatus(id int64, err error) error {
+	log.Warnf("Subscriber %d is dropped: %s", id, err.Error())
+	if errors.Is(err, service.ErrDisconnected) {
+		return status.Errorf(codes.Aborted, "subscriber %d is dropped: %s", id, err.Error())
+	}
+	return status.Errorf(codes.ResourceExhausted, "subscriber %d is dropped: %s", id, err.Error())
+}
+
+// describeClient reads the peer address and the client supplied name of a stream.
+func describeClient(ctx context.Context) service.SubscriberMeta {
+	meta := service.SubscriberMeta{}
+	if p, ok := peer.FromContext(ctx); ok {
+		meta.Peer = p.Addr.String()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if names := md.Get(clientNameKey); len(names) > 0 {
+			meta.Name = names[0]
+		}
+	}
+	return meta
 }
 
 func convertToPbKline(srvKline *service.Kline) *pb.Kline {
@@ -121,6 +345,7 @@ func convertToPbKline(srvKline *service.Kline) *pb.Kline {
 		TradeNum:                 srvKline.TradeNum,
 		TakerBuyBaseAssetVolume:  srvKline.TakerBuyBaseAssetVolume,
 		TakerBuyQuoteAssetVolume: srvKline.TakerBuyQuoteAssetVolume,
+		IsFinal:                  srvKline.IsFinal,
 	}
 }
 
@@ -138,3 +363,19 @@ func convertToStatus(status service.Status) pb.Status {
 		return pb.Status_UNKNOWN
 	}
 }
+
+func convertToBarType(barType pb.BarType) (service.BarType, error) {
+	switch barType {
+	case pb.BarType_TIME_BAR:
+		return service.TimeBar, nil
+	case pb.BarType_VOLUME_BAR:
+		return service.VolumeBar, nil
+	case pb.BarType_DOLLAR_BAR:
+		return service.DollarBar, nil
+	case pb.BarType_TICK_BAR:
+		return service.TickBar, nil
+	case pb.BarType_HEIKIN_ASHI:
+		return service.HeikinAshiBar, nil
+	}
+	return service.TimeBar, status.Errorf(codes.InvalidArgument, "unknown bar type %d", barType)
+}
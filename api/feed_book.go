@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+	"github.com/BullionBear/crypto-feed/pkg/service"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultBookDepth = 20
+
+func (s *feedServer) orderBookService(symbol string) (*service.OrderBookService, error) {
+	if s.bookMgr == nil {
+		return nil, status.Errorf(codes.Unimplemented, "order books are not enabled")
+	}
+	bookSrv, err := s.bookMgr.Get(symbol)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "order book of %s is not served: %s", symbol, err.Error())
+	}
+	return bookSrv, nil
+}
+
+// bookDepth checks a requested depth against the levels the service keeps per side.
+func bookDepth(depth int32, limit int) (int, error) {
+	if depth < 0 || int(depth) > limit {
+		return 0, status.Errorf(codes.InvalidArgument, "depth %d is out of [0, %d]", depth, limit)
+	}
+	if depth == 0 {
+		return min(defaultBookDepth, limit), nil
+	}
+	return int(depth), nil
+}
+
+func (s *feedServer) GetOrderBook(ctx context.Context, in *pb.OrderBookRequest) (*pb.OrderBookResponse, error) {
+	bookSrv, err := s.orderBookService(in.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	depth, err := bookDepth(in.Depth, bookSrv.Limit())
+	if err != nil {
+		return nil, err
+	}
+	book, err := bookSrv.Top(depth)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "order book of %s: %s", in.Symbol, err.Error())
+	}
+	return convertToPbOrderBook(book), nil
+}
+
+// SubscribeOrderBook streams the top levels of the order book whenever it changes, at most once per throttle.
+func (s *feedServer) SubscribeOrderBook(in *pb.SubscribeOrderBookRequest, stream pb.Feed_SubscribeOrderBookServer) error {
+	log.Infof("SubscribeOrderBook get called for %s", in.Symbol)
+	defer log.Info("Leave SubscribeOrderBook")
+	bookSrv, err := s.orderBookService(in.Symbol)
+	if err != nil {
+		return err
+	}
+	depth, err := bookDepth(in.Depth, bookSrv.Limit())
+	if err != nil {
+		return err
+	}
+	if in.Throttle < 0 {
+		return status.Errorf(codes.InvalidArgument, "throttle %d is negative", in.Throttle)
+	}
+	bookCh := make(chan *pb.OrderBookResponse)
+	doneCh := make(chan struct{})
+	book_handler := func(book *service.BookSnapshot) {
+		select {
+		case bookCh <- convertToPbOrderBook(book):
+		case <-doneCh:
+		}
+	}
+	id := bookSrv.Subscribe(depth, time.Duration(in.Throttle)*time.Millisecond, book_handler)
+	defer bookSrv.Unsubscribe(id)
+	defer close(doneCh) // Release a handler blocked on bookCh before unsubscribing
+	kickedCh, err := bookSrv.Kicked(id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "fail to watch subscriber %d: %s", id, err.Error())
+	}
+	bookSrv.DescribeSubscriber(id, describeClient(stream.Context()))
+	for {
+		select {
+		case book := <-bookCh:
+			if err := stream.Send(book); err != nil {
+				log.Warnf("Error sending data to client: %s", err.Error())
+				return nil
+			}
+			bookSrv.MarkSent(id)
+		case err := <-kickedCh:
+			return kickedStatus(id, err)
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func convertToPbOrderBook(book *service.BookSnapshot) *pb.OrderBookResponse {
+	return &pb.OrderBookResponse{
+		LastUpdateId: book.LastUpdateID,
+		Time:         book.Time,
+		Bids:         convertToPbPriceLevels(book.Bids),
+		Asks:         convertToPbPriceLevels(book.Asks),
+	}
+}
+
+func convertToPbPriceLevels(levels []service.PriceLevel) []*pb.PriceLevel {
+	pbLevels := make([]*pb.PriceLevel, len(levels))
+	for i, level := range levels {
+		pbLevels[i] = &pb.PriceLevel{
+			Price:    level.Price,
+			Quantity: level.Quantity,
+		}
+	}
+	return pbLevels
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"math"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+	"github.com/BullionBear/crypto-feed/pkg/service"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *feedServer) futuresService(symbol string) (*service.FuturesService, error) {
+	if s.futuresMgr == nil {
+		return nil, status.Errorf(codes.Unimplemented, "futures are not served")
+	}
+	futuresSrv, err := s.futuresMgr.Get(symbol)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "futures of %s are not served: %s", symbol, err.Error())
+	}
+	return futuresSrv, nil
+}
+
+func (s *feedServer) ReadFuturesKline(request *pb.ReadFuturesKlineRequest, stream pb.Feed_ReadFuturesKlineServer) error {
+	futuresSrv, err := s.futuresService(request.Symbol)
+	if err != nil {
+		return err
+	}
+	priceType, err := convertToPriceType(request.PriceType)
+	if err != nil {
+		return err
+	}
+	end := request.End
+	if end == 0 {
+		end = math.MaxInt64
+	}
+	var sendErr error
+	kline_handler := func(srvKline *service.Kline) error {
+		sendErr = stream.Send(&pb.KlineResponse{
+			Kline: convertToPbKline(srvKline),
+		})
+		return sendErr
+	}
+	if err := futuresSrv.Query(priceType, request.Start, end, request.Interval, kline_handler); err != nil {
+		if sendErr != nil {
+			log.Warnf("Error sending data to client: %s", sendErr.Error())
+			return sendErr
+		}
+		return status.Errorf(codes.InvalidArgument, "fail to read %s klines of %s: %s", request.PriceType, request.Symbol, err.Error())
+	}
+	return nil
+}
+
+func (s *feedServer) ReadFundingRate(request *pb.ReadFundingRateRequest, stream pb.Feed_ReadFundingRateServer) error {
+	futuresSrv, err := s.futuresService(request.Symbol)
+	if err != nil {
+		return err
+	}
+	end := request.End
+	if end == 0 {
+		end = math.MaxInt64
+	}
+	var sendErr error
+	rate_handler := func(srvRate *service.FundingRate) error {
+		sendErr = stream.Send(&pb.FundingRateResponse{
+			FundingRate: &pb.FundingRate{
+				FundingTime: srvRate.FundingTime,
+				Rate:        srvRate.Rate,
+				MarkPrice:   srvRate.MarkPrice,
+			},
+		})
+		return sendErr
+	}
+	if err := futuresSrv.QueryFunding(request.Start, end, rate_handler); err != nil {
+		if sendErr != nil {
+			log.Warnf("Error sending data to client: %s", sendErr.Error())
+			return sendErr
+		}
+		return status.Errorf(codes.Unavailable, "fail to read funding rates of %s: %s", request.Symbol, err.Error())
+	}
+	return nil
+}
+
+// SubscribeMarkPrice streams the mark price, index price and upcoming funding rate of a perpetual every second.
+func (s *feedServer) SubscribeMarkPrice(in *pb.SubscribeMarkPriceRequest, stream pb.Feed_SubscribeMarkPriceServer) error {
+	log.Infof("SubscribeMarkPrice get called for %s", in.Symbol)
+	defer log.Info("Leave SubscribeMarkPrice")
+	futuresSrv, err := s.futuresService(in.Symbol)
+	if err != nil {
+		return err
+	}
+	markCh := make(chan *pb.MarkPrice)
+	doneCh := make(chan struct{})
+	mark_handler := func(srvMark *service.MarkPriceUpdate) {
+		select {
+		case markCh <- convertToPbMarkPrice(srvMark):
+		case <-doneCh:
+		}
+	}
+	id := futuresSrv.Subscribe(mark_handler)
+	defer futuresSrv.Unsubscribe(id)
+	defer close(doneCh) // Release a handler blocked on markCh before unsubscribing
+	kickedCh, err := futuresSrv.Kicked(id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "fail to watch subscriber %d: %s", id, err.Error())
+	}
+	futuresSrv.DescribeSubscriber(id, describeClient(stream.Context()))
+	for {
+		select {
+		case markPrice := <-markCh:
+			if err := stream.Send(&pb.MarkPriceResponse{MarkPrice: markPrice}); err != nil {
+				log.Warnf("Error sending data to client: %s", err.Error())
+				return nil
+			}
+			futuresSrv.MarkSent(id)
+		case err := <-kickedCh:
+			return kickedStatus(id, err)
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func convertToPbMarkPrice(srvMark *service.MarkPriceUpdate) *pb.MarkPrice {
+	return &pb.MarkPrice{
+		Time:                 srvMark.Time,
+		MarkPrice:            srvMark.MarkPrice,
+		IndexPrice:           srvMark.IndexPrice,
+		EstimatedSettlePrice: srvMark.EstimatedSettlePrice,
+		FundingRate:          srvMark.FundingRate,
+		NextFundingTime:      srvMark.NextFundingTime,
+	}
+}
+
+func convertToPriceType(priceType pb.PriceType) (service.PriceType, error) {
+	switch priceType {
+	case pb.PriceType_CONTRACT_PRICE:
+		return service.ContractPrice, nil
+	case pb.PriceType_MARK_PRICE:
+		return service.MarkPrice, nil
+	case pb.PriceType_INDEX_PRICE:
+		return service.IndexPrice, nil
+	}
+	return service.ContractPrice, status.Errorf(codes.InvalidArgument, "unknown price type %d", priceType)
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"time"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+	"github.com/BullionBear/crypto-feed/pkg/indicator"
+	"github.com/BullionBear/crypto-feed/pkg/service"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const dayMs = int64(24 * time.Hour / time.Millisecond)
+
+const maxWarmup = 100_000
+
+// SubscribeIndicator streams the closed klines of an interval with the requested indicators.
+// The indicators are first fed the bars already in the container, so the first values streamed are settled.
+func (s *feedServer) SubscribeIndicator(in *pb.SubscribeIndicatorRequest, stream pb.Feed_SubscribeIndicatorServer) error {
+	log.Infof("SubscribeIndicator get called for %s", in.Symbol)
+	defer log.Info("Leave SubscribeIndicator")
+	klineSrv, err := s.klineService(in.Symbol)
+	if err != nil {
+		return err
+	}
+	width, err := service.IntervalMs(in.Interval)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "fail to read interval %s: %s", in.Interval, err.Error())
+	}
+	if len(in.Indicators) == 0 {
+		return status.Errorf(codes.InvalidArgument, "no indicator is requested")
+	}
+	if in.Warmup < 0 || in.Warmup > maxWarmup {
+		return status.Errorf(codes.InvalidArgument, "warmup %d is out of [0, %d]", in.Warmup, maxWarmup)
+	}
+	indicators := make([]indicator.Indicator, len(in.Indicators))
+	keys := make([]string, len(in.Indicators))
+	warmup, isDaily := 0, false
+	for i, spec := range in.Indicators {
+		if indicators[i], err = indicator.New(spec.Name, spec.Params); err != nil {
+			return status.Errorf(codes.InvalidArgument, "fail to create indicator %s: %s", spec.Name, err.Error())
+		}
+		keys[i] = indicator.Key(spec.Name, spec.Params)
+		if bars := indicators[i].Warmup(); bars == indicator.WarmupDay {
+			isDaily = true
+		} else if bars > warmup {
+			warmup = bars
+		}
+	}
+	if in.Warmup > 0 {
+		warmup, isDaily = int(in.Warmup), false
+	}
+
+	tail, err := klineSrv.Tail()
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "no kline of %s yet: %s", klineSrv.Symbol(), err.Error())
+	}
+	// Bars opened before live only warm the indicators up
+	live := tail.OpenTime - tail.OpenTime%width
+	from := live - int64(warmup)*width
+	if dayStart := live - live%dayMs; isDaily && dayStart < from {
+		from = dayStart
+	}
+
+	responseCh := make(chan *pb.IndicatorResponse)
+	doneCh := make(chan struct{})
+	bar_handler := func(bar *service.Kline) {
+		for _, ind := range indicators {
+			ind.Update(bar)
+		}
+		if bar.OpenTime < live {
+			return
+		}
+		response := &pb.IndicatorResponse{
+			Kline:      convertToPbKline(bar),
+			Indicators: make([]*pb.IndicatorValue, len(indicators)),
+		}
+		for i, ind := range indicators {
+			response.Indicators[i] = &pb.IndicatorValue{
+				Key:    keys[i],
+				Values: ind.Values(),
+				Ready:  ind.Ready(),
+			}
+		}
+		select {
+		case responseCh <- response:
+		case <-doneCh:
+		}
+	}
+	id, err := klineSrv.SubscribeSince(from, in.Interval, bar_handler)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "fail to warm up from %d: %s", from, err.Error())
+	}
+	defer klineSrv.Unsubscribe(id)
+	defer close(doneCh) // Release a handler blocked on responseCh before unsubscribing
+	kickedCh, err := klineSrv.Kicked(id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "fail to watch subscriber %d: %s", id, err.Error())
+	}
+	klineSrv.DescribeSubscriber(id, describeClient(stream.Context()))
+	for {
+		select {
+		case response := <-responseCh:
+			if err := stream.Send(response); err != nil {
+				log.Warnf("Error sending data to client: %s", err.Error())
+				return nil
+			}
+			klineSrv.MarkSent(id)
+		case err := <-kickedCh:
+			return kickedStatus(id, err)
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
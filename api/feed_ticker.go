@@ -0,0 +1,83 @@
+package api
+
+import (
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+	"github.com/BullionBear/crypto-feed/pkg/service"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *feedServer) bookTickerService(symbol string) (*service.BookTickerService, error) {
+	if s.tickerMgr == nil {
+		return nil, status.Errorf(codes.Unimplemented, "book tickers are not enabled")
+	}
+	tickerSrv, err := s.tickerMgr.Get(symbol)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "book ticker of %s is not served: %s", symbol, err.Error())
+	}
+	return tickerSrv, nil
+}
+
+// SubscribeBookTicker streams the best bid and ask of a symbol every time one of them changes.
+func (s *feedServer) SubscribeBookTicker(in *pb.SubscribeBookTickerRequest, stream pb.Feed_SubscribeBookTickerServer) error {
+	log.Infof("SubscribeBookTicker get called for %s", in.Symbol)
+	defer log.Info("Leave SubscribeBookTicker")
+	tickerSrv, err := s.bookTickerService(in.Symbol)
+	if err != nil {
+		return err
+	}
+	tickerCh := make(chan *pb.BookTicker)
+	doneCh := make(chan struct{})
+	ticker_handler := func(srvTicker *service.BookTicker) {
+		select {
+		case tickerCh <- convertToPbBookTicker(srvTicker):
+		case <-doneCh:
+		}
+	}
+	id := tickerSrv.Subscribe(ticker_handler)
+	defer tickerSrv.Unsubscribe(id)
+	defer close(doneCh) // Release a handler blocked on tickerCh before unsubscribing
+	kickedCh, err := tickerSrv.Kicked(id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "fail to watch subscriber %d: %s", id, err.Error())
+	}
+	tickerSrv.DescribeSubscriber(id, describeClient(stream.Context()))
+	for {
+		select {
+		case ticker := <-tickerCh:
+			if err := stream.Send(&pb.BookTickerResponse{BookTicker: ticker}); err != nil {
+				log.Warnf("Error sending data to client: %s", err.Error())
+				return nil
+			}
+			tickerSrv.MarkSent(id)
+		case err := <-kickedCh:
+			return kickedStatus(id, err)
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func convertToPbBookTicker(srvTicker *service.BookTicker) *pb.BookTicker {
+	return &pb.BookTicker{
+		UpdateId:    srvTicker.UpdateID,
+		Time:        srvTicker.Time,
+		BidPrice:    srvTicker.BidPrice,
+		BidQuantity: srvTicker.BidQuantity,
+		AskPrice:    srvTicker.AskPrice,
+		AskQuantity: srvTicker.AskQuantity,
+	}
+}
+
+func convertToPbSpread(srvSpread *service.Spread) *pb.Spread {
+	return &pb.Spread{
+		OpenTime:   srvSpread.OpenTime,
+		Bid:        srvSpread.Bid,
+		Ask:        srvSpread.Ask,
+		Mid:        srvSpread.Mid,
+		Spread:     srvSpread.Spread,
+		MeanSpread: srvSpread.MeanSpread,
+		Updates:    srvSpread.Updates,
+	}
+}
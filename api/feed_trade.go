@@ -0,0 +1,100 @@
+package api
+
+import (
+	"math"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+	"github.com/BullionBear/crypto-feed/pkg/service"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *feedServer) tradeService(symbol string) (*service.TradeService, error) {
+	if s.tradeMgr == nil {
+		return nil, status.Errorf(codes.Unimplemented, "trades are not enabled")
+	}
+	tradeSrv, err := s.tradeMgr.Get(symbol)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "trades of %s are not served: %s", symbol, err.Error())
+	}
+	return tradeSrv, nil
+}
+
+// SubscribeTrades streams every trade of a symbol in id order from the moment of the subscription.
+func (s *feedServer) SubscribeTrades(in *pb.SubscribeTradesRequest, stream pb.Feed_SubscribeTradesServer) error {
+	log.Infof("SubscribeTrades get called for %s", in.Symbol)
+	defer log.Info("Leave SubscribeTrades")
+	tradeSrv, err := s.tradeService(in.Symbol)
+	if err != nil {
+		return err
+	}
+	tradeCh := make(chan *pb.Trade)
+	doneCh := make(chan struct{})
+	trade_handler := func(srvTrade *service.Trade) {
+		select {
+		case tradeCh <- convertToPbTrade(srvTrade):
+		case <-doneCh:
+		}
+	}
+	id := tradeSrv.Subscribe(trade_handler)
+	defer tradeSrv.Unsubscribe(id)
+	defer close(doneCh) // Release a handler blocked on tradeCh before unsubscribing
+	kickedCh, err := tradeSrv.Kicked(id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "fail to watch subscriber %d: %s", id, err.Error())
+	}
+	tradeSrv.DescribeSubscriber(id, describeClient(stream.Context()))
+	for {
+		select {
+		case trade := <-tradeCh:
+			if err := stream.Send(&pb.TradeResponse{Trade: trade}); err != nil {
+				log.Warnf("Error sending data to client: %s", err.Error())
+				return nil
+			}
+			tradeSrv.MarkSent(id)
+		case err := <-kickedCh:
+			return kickedStatus(id, err)
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (s *feedServer) ReadHistoricalTrades(request *pb.ReadTradesRequest, stream pb.Feed_ReadHistoricalTradesServer) error {
+	tradeSrv, err := s.tradeService(request.Symbol)
+	if err != nil {
+		return err
+	}
+	end := request.End
+	if end == 0 {
+		end = math.MaxInt64
+	}
+	var sendErr error
+	trade_handler := func(srvTrade *service.Trade) error {
+		sendErr = stream.Send(&pb.TradeResponse{
+			Trade: convertToPbTrade(srvTrade),
+		})
+		return sendErr
+	}
+	if err := tradeSrv.Query(request.Start, end, request.FromId, trade_handler); err != nil {
+		if sendErr != nil {
+			log.Warnf("Error sending data to client: %s", sendErr.Error())
+			return sendErr
+		}
+		return status.Errorf(codes.OutOfRange, "fail to read trades of %s: %s", request.Symbol, err.Error())
+	}
+	return nil
+}
+
+func convertToPbTrade(srvTrade *service.Trade) *pb.Trade {
+	return &pb.Trade{
+		Id:           srvTrade.ID,
+		Price:        srvTrade.Price,
+		Quantity:     srvTrade.Quantity,
+		FirstTradeId: srvTrade.FirstTradeID,
+		LastTradeId:  srvTrade.LastTradeID,
+		Time:         srvTrade.Time,
+		IsBuyerMaker: srvTrade.IsBuyerMaker,
+	}
+}
@@ -9,7 +9,6 @@ package feed
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -21,6 +20,110 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type BarType int32
+
+const (
+	BarType_TIME_BAR    BarType = 0 // Bars of interval
+	BarType_VOLUME_BAR  BarType = 1 // Closed once the base asset volume reaches threshold
+	BarType_DOLLAR_BAR  BarType = 2 // Closed once the quote asset volume reaches threshold
+	BarType_TICK_BAR    BarType = 3 // Closed once the trade count reaches threshold
+	BarType_HEIKIN_ASHI BarType = 4 // Heikin-Ashi candles of interval
+)
+
+// Enum value maps for BarType.
+var (
+	BarType_name = map[int32]string{
+		0: "TIME_BAR",
+		1: "VOLUME_BAR",
+		2: "DOLLAR_BAR",
+		3: "TICK_BAR",
+		4: "HEIKIN_ASHI",
+	}
+	BarType_value = map[string]int32{
+		"TIME_BAR":    0,
+		"VOLUME_BAR":  1,
+		"DOLLAR_BAR":  2,
+		"TICK_BAR":    3,
+		"HEIKIN_ASHI": 4,
+	}
+)
+
+func (x BarType) Enum() *BarType {
+	p := new(BarType)
+	*p = x
+	return p
+}
+
+func (x BarType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BarType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_feed_proto_enumTypes[0].Descriptor()
+}
+
+func (BarType) Type() protoreflect.EnumType {
+	return &file_api_proto_feed_proto_enumTypes[0]
+}
+
+func (x BarType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BarType.Descriptor instead.
+func (BarType) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{0}
+}
+
+type PriceType int32
+
+const (
+	PriceType_CONTRACT_PRICE PriceType = 0 // Klines of the traded contract
+	PriceType_MARK_PRICE     PriceType = 1 // Klines of the mark price, volumes are zero
+	PriceType_INDEX_PRICE    PriceType = 2 // Klines of the index price, volumes are zero
+)
+
+// Enum value maps for PriceType.
+var (
+	PriceType_name = map[int32]string{
+		0: "CONTRACT_PRICE",
+		1: "MARK_PRICE",
+		2: "INDEX_PRICE",
+	}
+	PriceType_value = map[string]int32{
+		"CONTRACT_PRICE": 0,
+		"MARK_PRICE":     1,
+		"INDEX_PRICE":    2,
+	}
+)
+
+func (x PriceType) Enum() *PriceType {
+	p := new(PriceType)
+	*p = x
+	return p
+}
+
+func (x PriceType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PriceType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_feed_proto_enumTypes[1].Descriptor()
+}
+
+func (PriceType) Type() protoreflect.EnumType {
+	return &file_api_proto_feed_proto_enumTypes[1]
+}
+
+func (x PriceType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PriceType.Descriptor instead.
+func (PriceType) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{1}
+}
+
 type Status int32
 
 const (
@@ -60,11 +163,11 @@ func (x Status) String() string {
 }
 
 func (Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_feed_proto_enumTypes[0].Descriptor()
+	return file_api_proto_feed_proto_enumTypes[2].Descriptor()
 }
 
 func (Status) Type() protoreflect.EnumType {
-	return &file_api_proto_feed_proto_enumTypes[0]
+	return &file_api_proto_feed_proto_enumTypes[2]
 }
 
 func (x Status) Number() protoreflect.EnumNumber {
@@ -73,7 +176,114 @@ func (x Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Status.Descriptor instead.
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_feed_proto_rawDescGZIP(), []int{0}
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{2}
+}
+
+type PlaybackAction int32
+
+const (
+	PlaybackAction_PLAYBACK_QUERY  PlaybackAction = 0
+	PlaybackAction_PLAYBACK_PAUSE  PlaybackAction = 1
+	PlaybackAction_PLAYBACK_RESUME PlaybackAction = 2
+	PlaybackAction_PLAYBACK_SEEK   PlaybackAction = 3
+	PlaybackAction_PLAYBACK_SPEED  PlaybackAction = 4
+)
+
+// Enum value maps for PlaybackAction.
+var (
+	PlaybackAction_name = map[int32]string{
+		0: "PLAYBACK_QUERY",
+		1: "PLAYBACK_PAUSE",
+		2: "PLAYBACK_RESUME",
+		3: "PLAYBACK_SEEK",
+		4: "PLAYBACK_SPEED",
+	}
+	PlaybackAction_value = map[string]int32{
+		"PLAYBACK_QUERY":  0,
+		"PLAYBACK_PAUSE":  1,
+		"PLAYBACK_RESUME": 2,
+		"PLAYBACK_SEEK":   3,
+		"PLAYBACK_SPEED":  4,
+	}
+)
+
+func (x PlaybackAction) Enum() *PlaybackAction {
+	p := new(PlaybackAction)
+	*p = x
+	return p
+}
+
+func (x PlaybackAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PlaybackAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_feed_proto_enumTypes[3].Descriptor()
+}
+
+func (PlaybackAction) Type() protoreflect.EnumType {
+	return &file_api_proto_feed_proto_enumTypes[3]
+}
+
+func (x PlaybackAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PlaybackAction.Descriptor instead.
+func (PlaybackAction) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{3}
+}
+
+type PlaybackStatus int32
+
+const (
+	PlaybackStatus_PLAYBACK_OPENED    PlaybackStatus = 0
+	PlaybackStatus_PLAYBACK_PLAYING   PlaybackStatus = 1
+	PlaybackStatus_PLAYBACK_FINISHED  PlaybackStatus = 2
+	PlaybackStatus_PLAYBACK_CANCELLED PlaybackStatus = 3
+)
+
+// Enum value maps for PlaybackStatus.
+var (
+	PlaybackStatus_name = map[int32]string{
+		0: "PLAYBACK_OPENED",
+		1: "PLAYBACK_PLAYING",
+		2: "PLAYBACK_FINISHED",
+		3: "PLAYBACK_CANCELLED",
+	}
+	PlaybackStatus_value = map[string]int32{
+		"PLAYBACK_OPENED":    0,
+		"PLAYBACK_PLAYING":   1,
+		"PLAYBACK_FINISHED":  2,
+		"PLAYBACK_CANCELLED": 3,
+	}
+)
+
+func (x PlaybackStatus) Enum() *PlaybackStatus {
+	p := new(PlaybackStatus)
+	*p = x
+	return p
+}
+
+func (x PlaybackStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PlaybackStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_feed_proto_enumTypes[4].Descriptor()
+}
+
+func (PlaybackStatus) Type() protoreflect.EnumType {
+	return &file_api_proto_feed_proto_enumTypes[4]
+}
+
+func (x PlaybackStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PlaybackStatus.Descriptor instead.
+func (PlaybackStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{4}
 }
 
 type Kline struct {
@@ -92,6 +302,8 @@ type Kline struct {
 	TradeNum                 int64   `protobuf:"varint,9,opt,name=tradeNum,proto3" json:"tradeNum,omitempty"`
 	TakerBuyBaseAssetVolume  float64 `protobuf:"fixed64,10,opt,name=takerBuyBaseAssetVolume,proto3" json:"takerBuyBaseAssetVolume,omitempty"`
 	TakerBuyQuoteAssetVolume float64 `protobuf:"fixed64,11,opt,name=takerBuyQuoteAssetVolume,proto3" json:"takerBuyQuoteAssetVolume,omitempty"`
+	IsFinal                  bool    `protobuf:"varint,12,opt,name=isFinal,proto3" json:"isFinal,omitempty"` // False while the kline is still in progress
+	Symbol                   string  `protobuf:"bytes,13,opt,name=symbol,proto3" json:"symbol,omitempty"`    // Set by multi-symbol playback
 }
 
 func (x *Kline) Reset() {
@@ -203,17 +415,30 @@ func (x *Kline) GetTakerBuyQuoteAssetVolume() float64 {
 	return 0
 }
 
-type ReadKlineRequest struct {
+func (x *Kline) GetIsFinal() bool {
+	if x != nil {
+		return x.IsFinal
+	}
+	return false
+}
+
+func (x *Kline) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type ConfigRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Start int64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
-	End   int64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
 }
 
-func (x *ReadKlineRequest) Reset() {
-	*x = ReadKlineRequest{}
+func (x *ConfigRequest) Reset() {
+	*x = ConfigRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_feed_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -221,13 +446,13 @@ func (x *ReadKlineRequest) Reset() {
 	}
 }
 
-func (x *ReadKlineRequest) String() string {
+func (x *ConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadKlineRequest) ProtoMessage() {}
+func (*ConfigRequest) ProtoMessage() {}
 
-func (x *ReadKlineRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfigRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_feed_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -239,39 +464,28 @@ func (x *ReadKlineRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadKlineRequest.ProtoReflect.Descriptor instead.
-func (*ReadKlineRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigRequest.ProtoReflect.Descriptor instead.
+func (*ConfigRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_feed_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *ReadKlineRequest) GetStart() int64 {
-	if x != nil {
-		return x.Start
-	}
-	return 0
-}
-
-func (x *ReadKlineRequest) GetEnd() int64 {
+func (x *ConfigRequest) GetSymbol() string {
 	if x != nil {
-		return x.End
+		return x.Symbol
 	}
-	return 0
+	return ""
 }
 
-type StatusResponse struct {
+type StatusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status    Status `protobuf:"varint,1,opt,name=status,proto3,enum=feed.Status" json:"status,omitempty"`
-	Start     int64  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
-	End       int64  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
-	Timestamp int64  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Size      int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
 }
 
-func (x *StatusResponse) Reset() {
-	*x = StatusResponse{}
+func (x *StatusRequest) Reset() {
+	*x = StatusRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_feed_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -279,13 +493,13 @@ func (x *StatusResponse) Reset() {
 	}
 }
 
-func (x *StatusResponse) String() string {
+func (x *StatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse) ProtoMessage() {}
+func (*StatusRequest) ProtoMessage() {}
 
-func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+func (x *StatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_feed_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -297,57 +511,28 @@ func (x *StatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
-func (*StatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
+func (*StatusRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_feed_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *StatusResponse) GetStatus() Status {
-	if x != nil {
-		return x.Status
-	}
-	return Status_CREATED
-}
-
-func (x *StatusResponse) GetStart() int64 {
-	if x != nil {
-		return x.Start
-	}
-	return 0
-}
-
-func (x *StatusResponse) GetEnd() int64 {
-	if x != nil {
-		return x.End
-	}
-	return 0
-}
-
-func (x *StatusResponse) GetTimestamp() int64 {
-	if x != nil {
-		return x.Timestamp
-	}
-	return 0
-}
-
-func (x *StatusResponse) GetSize() int64 {
+func (x *StatusRequest) GetSymbol() string {
 	if x != nil {
-		return x.Size
+		return x.Symbol
 	}
-	return 0
+	return ""
 }
 
-type ConfigResponse struct {
+type SubscriberRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Length int64  `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
 }
 
-func (x *ConfigResponse) Reset() {
-	*x = ConfigResponse{}
+func (x *SubscriberRequest) Reset() {
+	*x = SubscriberRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_feed_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -355,13 +540,13 @@ func (x *ConfigResponse) Reset() {
 	}
 }
 
-func (x *ConfigResponse) String() string {
+func (x *SubscriberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigResponse) ProtoMessage() {}
+func (*SubscriberRequest) ProtoMessage() {}
 
-func (x *ConfigResponse) ProtoReflect() protoreflect.Message {
+func (x *SubscriberRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_feed_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -373,35 +558,36 @@ func (x *ConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigResponse.ProtoReflect.Descriptor instead.
-func (*ConfigResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SubscriberRequest.ProtoReflect.Descriptor instead.
+func (*SubscriberRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_feed_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ConfigResponse) GetSymbol() string {
+func (x *SubscriberRequest) GetSymbol() string {
 	if x != nil {
 		return x.Symbol
 	}
 	return ""
 }
 
-func (x *ConfigResponse) GetLength() int64 {
-	if x != nil {
-		return x.Length
-	}
-	return 0
-}
-
-type SubscriberResponse struct {
+type SubscribeKlineRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Subscribers []int64 `protobuf:"varint,1,rep,packed,name=subscribers,proto3" json:"subscribers,omitempty"`
+	Symbol       string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Interval     string   `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`                  // 1s, 1m, 5m, 15m, 1h or 1d, empty means 1s
+	Updates      bool     `protobuf:"varint,3,opt,name=updates,proto3" json:"updates,omitempty"`                   // Receive every intra-bar update instead of closed klines only, 1s only
+	FromOpenTime int64    `protobuf:"varint,4,opt,name=fromOpenTime,proto3" json:"fromOpenTime,omitempty"`         // Replay closed klines from this open time before going live, 0 starts live, OUT_OF_RANGE once it left the window
+	Speed        float64  `protobuf:"fixed64,5,opt,name=speed,proto3" json:"speed,omitempty"`                      // Playback only, multiplier of the real kline spacing, 0 uses the server default
+	SessionId    int64    `protobuf:"varint,6,opt,name=sessionId,proto3" json:"sessionId,omitempty"`               // Playback only, stream a session from OpenPlayback, 0 opens one for this stream
+	Symbols      []string `protobuf:"bytes,7,rep,name=symbols,proto3" json:"symbols,omitempty"`                    // Playback only, merge these symbols by open time instead of symbol
+	BarType      BarType  `protobuf:"varint,8,opt,name=barType,proto3,enum=feed.BarType" json:"barType,omitempty"` // Live feed only, volume, dollar and tick bars are built from 1s klines
+	Threshold    float64  `protobuf:"fixed64,9,opt,name=threshold,proto3" json:"threshold,omitempty"`              // Volume, dollar or tick bars, the sum closing a bar
 }
 
-func (x *SubscriberResponse) Reset() {
-	*x = SubscriberResponse{}
+func (x *SubscribeKlineRequest) Reset() {
+	*x = SubscribeKlineRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_feed_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -409,13 +595,13 @@ func (x *SubscriberResponse) Reset() {
 	}
 }
 
-func (x *SubscriberResponse) String() string {
+func (x *SubscribeKlineRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscriberResponse) ProtoMessage() {}
+func (*SubscribeKlineRequest) ProtoMessage() {}
 
-func (x *SubscriberResponse) ProtoReflect() protoreflect.Message {
+func (x *SubscribeKlineRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_feed_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -427,61 +613,2657 @@ func (x *SubscriberResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscriberResponse.ProtoReflect.Descriptor instead.
-func (*SubscriberResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SubscribeKlineRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeKlineRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_feed_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *SubscriberResponse) GetSubscribers() []int64 {
+func (x *SubscribeKlineRequest) GetSymbol() string {
 	if x != nil {
-		return x.Subscribers
+		return x.Symbol
 	}
-	return nil
+	return ""
 }
 
-type KlineResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Kline *Kline `protobuf:"bytes,1,opt,name=kline,proto3" json:"kline,omitempty"`
+func (x *SubscribeKlineRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
 }
 
-func (x *KlineResponse) Reset() {
-	*x = KlineResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_feed_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *SubscribeKlineRequest) GetUpdates() bool {
+	if x != nil {
+		return x.Updates
 	}
+	return false
 }
 
-func (x *KlineResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SubscribeKlineRequest) GetFromOpenTime() int64 {
+	if x != nil {
+		return x.FromOpenTime
+	}
+	return 0
 }
 
-func (*KlineResponse) ProtoMessage() {}
+func (x *SubscribeKlineRequest) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
 
-func (x *KlineResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_feed_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *SubscribeKlineRequest) GetSessionId() int64 {
+	if x != nil {
+		return x.SessionId
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use KlineResponse.ProtoReflect.Descriptor instead.
-func (*KlineResponse) Descriptor() ([]byte, []int) {
+func (x *SubscribeKlineRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+func (x *SubscribeKlineRequest) GetBarType() BarType {
+	if x != nil {
+		return x.BarType
+	}
+	return BarType_TIME_BAR
+}
+
+func (x *SubscribeKlineRequest) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+type ReadKlineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start      int64   `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End        int64   `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	Symbol     string  `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Interval   string  `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`                  // 1s, 1m, 5m, 15m, 1h or 1d, empty means 1s
+	BarType    BarType `protobuf:"varint,5,opt,name=barType,proto3,enum=feed.BarType" json:"barType,omitempty"` // Live feed only
+	Threshold  float64 `protobuf:"fixed64,6,opt,name=threshold,proto3" json:"threshold,omitempty"`              // Volume, dollar or tick bars, the sum closing a bar
+	WithSpread bool    `protobuf:"varint,7,opt,name=withSpread,proto3" json:"withSpread,omitempty"`             // Live feed only, join the spread of the last second of every bar
+}
+
+func (x *ReadKlineRequest) Reset() {
+	*x = ReadKlineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadKlineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadKlineRequest) ProtoMessage() {}
+
+func (x *ReadKlineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadKlineRequest.ProtoReflect.Descriptor instead.
+func (*ReadKlineRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_feed_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *KlineResponse) GetKline() *Kline {
+func (x *ReadKlineRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ReadKlineRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *ReadKlineRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ReadKlineRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *ReadKlineRequest) GetBarType() BarType {
+	if x != nil {
+		return x.BarType
+	}
+	return BarType_TIME_BAR
+}
+
+func (x *ReadKlineRequest) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *ReadKlineRequest) GetWithSpread() bool {
+	if x != nil {
+		return x.WithSpread
+	}
+	return false
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status    Status `protobuf:"varint,1,opt,name=status,proto3,enum=feed.Status" json:"status,omitempty"`
+	Start     int64  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End       int64  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	Timestamp int64  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Size      int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Gaps      int64  `protobuf:"varint,6,opt,name=gaps,proto3" json:"gaps,omitempty"` // Missing ranges waiting to be backfilled
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StatusResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_CREATED
+}
+
+func (x *StatusResponse) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetGaps() int64 {
+	if x != nil {
+		return x.Gaps
+	}
+	return 0
+}
+
+type ConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Length int64  `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+}
+
+func (x *ConfigResponse) Reset() {
+	*x = ConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigResponse) ProtoMessage() {}
+
+func (x *ConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigResponse.ProtoReflect.Descriptor instead.
+func (*ConfigResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ConfigResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ConfigResponse) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type SubscriberResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subscribers []int64           `protobuf:"varint,1,rep,packed,name=subscribers,proto3" json:"subscribers,omitempty"` // Kline subscribers only, infos covers every stream
+	Infos       []*SubscriberInfo `protobuf:"bytes,2,rep,name=infos,proto3" json:"infos,omitempty"`
+}
+
+func (x *SubscriberResponse) Reset() {
+	*x = SubscriberResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriberResponse) ProtoMessage() {}
+
+func (x *SubscriberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriberResponse.ProtoReflect.Descriptor instead.
+func (*SubscriberResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SubscriberResponse) GetSubscribers() []int64 {
+	if x != nil {
+		return x.Subscribers
+	}
+	return nil
+}
+
+func (x *SubscriberResponse) GetInfos() []*SubscriberInfo {
+	if x != nil {
+		return x.Infos
+	}
+	return nil
+}
+
+type SubscriberInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Dropped      int64  `protobuf:"varint,2,opt,name=dropped,proto3" json:"dropped,omitempty"` // Klines dropped because the subscriber queue was full
+	Peer         string `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+	Name         string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"` // Client supplied through the client-name metadata
+	ConnectTime  int64  `protobuf:"varint,5,opt,name=connectTime,proto3" json:"connectTime,omitempty"`
+	Sent         int64  `protobuf:"varint,6,opt,name=sent,proto3" json:"sent,omitempty"`
+	LastSendTime int64  `protobuf:"varint,7,opt,name=lastSendTime,proto3" json:"lastSendTime,omitempty"`
+	QueueDepth   int64  `protobuf:"varint,8,opt,name=queueDepth,proto3" json:"queueDepth,omitempty"`
+	Stream       string `protobuf:"bytes,9,opt,name=stream,proto3" json:"stream,omitempty"` // kline, trade, order_book, book_ticker or mark_price, ids are unique within a stream
+}
+
+func (x *SubscriberInfo) Reset() {
+	*x = SubscriberInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriberInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriberInfo) ProtoMessage() {}
+
+func (x *SubscriberInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriberInfo.ProtoReflect.Descriptor instead.
+func (*SubscriberInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubscriberInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SubscriberInfo) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *SubscriberInfo) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *SubscriberInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubscriberInfo) GetConnectTime() int64 {
+	if x != nil {
+		return x.ConnectTime
+	}
+	return 0
+}
+
+func (x *SubscriberInfo) GetSent() int64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *SubscriberInfo) GetLastSendTime() int64 {
+	if x != nil {
+		return x.LastSendTime
+	}
+	return 0
+}
+
+func (x *SubscriberInfo) GetQueueDepth() int64 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *SubscriberInfo) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+type DisconnectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Id     int64  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Stream string `protobuf:"bytes,3,opt,name=stream,proto3" json:"stream,omitempty"` // Stream of the subscriber as in SubscriberInfo, empty means kline
+}
+
+func (x *DisconnectRequest) Reset() {
+	*x = DisconnectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisconnectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectRequest) ProtoMessage() {}
+
+func (x *DisconnectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectRequest.ProtoReflect.Descriptor instead.
+func (*DisconnectRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DisconnectRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *DisconnectRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DisconnectRequest) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+type DisconnectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DisconnectResponse) Reset() {
+	*x = DisconnectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisconnectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectResponse) ProtoMessage() {}
+
+func (x *DisconnectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectResponse.ProtoReflect.Descriptor instead.
+func (*DisconnectResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DisconnectResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type OpenPlaybackRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol   string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`     // Empty uses the configured symbol
+	Start    int64    `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`      // 0 uses the configured start time
+	End      int64    `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`          // 0 uses the configured end time
+	Interval string   `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"` // 1s, 1m, 5m, 15m, 1h or 1d, empty means 1s
+	Speed    float64  `protobuf:"fixed64,5,opt,name=speed,proto3" json:"speed,omitempty"`     // Multiplier of the real kline spacing, 0 uses the server default
+	Symbols  []string `protobuf:"bytes,6,rep,name=symbols,proto3" json:"symbols,omitempty"`   // Merge these symbols by open time, ties follow this order, overrides symbol
+}
+
+func (x *OpenPlaybackRequest) Reset() {
+	*x = OpenPlaybackRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenPlaybackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenPlaybackRequest) ProtoMessage() {}
+
+func (x *OpenPlaybackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenPlaybackRequest.ProtoReflect.Descriptor instead.
+func (*OpenPlaybackRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OpenPlaybackRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OpenPlaybackRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *OpenPlaybackRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *OpenPlaybackRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *OpenPlaybackRequest) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *OpenPlaybackRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+type PlaybackSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // 0 for every session
+}
+
+func (x *PlaybackSessionRequest) Reset() {
+	*x = PlaybackSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaybackSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybackSessionRequest) ProtoMessage() {}
+
+func (x *PlaybackSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybackSessionRequest.ProtoReflect.Descriptor instead.
+func (*PlaybackSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PlaybackSessionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type PlaybackSessionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions []*PlaybackState `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *PlaybackSessionResponse) Reset() {
+	*x = PlaybackSessionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaybackSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybackSessionResponse) ProtoMessage() {}
+
+func (x *PlaybackSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybackSessionResponse.ProtoReflect.Descriptor instead.
+func (*PlaybackSessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PlaybackSessionResponse) GetSessions() []*PlaybackState {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type PlaybackControlRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int64          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // Session id, also sent in the subscriber-id response header, 0 for every session
+	Action   PlaybackAction `protobuf:"varint,2,opt,name=action,proto3,enum=feed.PlaybackAction" json:"action,omitempty"`
+	Speed    float64        `protobuf:"fixed64,3,opt,name=speed,proto3" json:"speed,omitempty"`      // PLAYBACK_SPEED, multiplier of the real kline spacing, 0 sends as fast as possible
+	SeekTime int64          `protobuf:"varint,4,opt,name=seekTime,proto3" json:"seekTime,omitempty"` // PLAYBACK_SEEK, open time to continue from
+}
+
+func (x *PlaybackControlRequest) Reset() {
+	*x = PlaybackControlRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaybackControlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybackControlRequest) ProtoMessage() {}
+
+func (x *PlaybackControlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybackControlRequest.ProtoReflect.Descriptor instead.
+func (*PlaybackControlRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PlaybackControlRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PlaybackControlRequest) GetAction() PlaybackAction {
+	if x != nil {
+		return x.Action
+	}
+	return PlaybackAction_PLAYBACK_QUERY
+}
+
+func (x *PlaybackControlRequest) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *PlaybackControlRequest) GetSeekTime() int64 {
+	if x != nil {
+		return x.SeekTime
+	}
+	return 0
+}
+
+type PlaybackControlResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	States []*PlaybackState `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+}
+
+func (x *PlaybackControlResponse) Reset() {
+	*x = PlaybackControlResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaybackControlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybackControlResponse) ProtoMessage() {}
+
+func (x *PlaybackControlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybackControlResponse.ProtoReflect.Descriptor instead.
+func (*PlaybackControlResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PlaybackControlResponse) GetStates() []*PlaybackState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type PlaybackState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int64          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Speed       float64        `protobuf:"fixed64,2,opt,name=speed,proto3" json:"speed,omitempty"`
+	Paused      bool           `protobuf:"varint,3,opt,name=paused,proto3" json:"paused,omitempty"`
+	CurrentTime int64          `protobuf:"varint,4,opt,name=currentTime,proto3" json:"currentTime,omitempty"` // Open time of the last kline sent
+	Symbol      string         `protobuf:"bytes,5,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Start       int64          `protobuf:"varint,6,opt,name=start,proto3" json:"start,omitempty"`
+	End         int64          `protobuf:"varint,7,opt,name=end,proto3" json:"end,omitempty"`
+	Interval    string         `protobuf:"bytes,8,opt,name=interval,proto3" json:"interval,omitempty"`
+	Status      PlaybackStatus `protobuf:"varint,9,opt,name=status,proto3,enum=feed.PlaybackStatus" json:"status,omitempty"`
+	Symbols     []string       `protobuf:"bytes,10,rep,name=symbols,proto3" json:"symbols,omitempty"`
+}
+
+func (x *PlaybackState) Reset() {
+	*x = PlaybackState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaybackState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybackState) ProtoMessage() {}
+
+func (x *PlaybackState) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybackState.ProtoReflect.Descriptor instead.
+func (*PlaybackState) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PlaybackState) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PlaybackState) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *PlaybackState) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *PlaybackState) GetCurrentTime() int64 {
+	if x != nil {
+		return x.CurrentTime
+	}
+	return 0
+}
+
+func (x *PlaybackState) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *PlaybackState) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *PlaybackState) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *PlaybackState) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *PlaybackState) GetStatus() PlaybackStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PlaybackStatus_PLAYBACK_OPENED
+}
+
+func (x *PlaybackState) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+type LockstepRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start     int64  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`         // First message only, 0 uses the playback start time
+	End       int64  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`             // First message only, 0 uses the playback end time
+	BatchSize int32  `protobuf:"varint,3,opt,name=batchSize,proto3" json:"batchSize,omitempty"` // First message only, klines per batch, 0 means 1
+	Interval  string `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`    // First message only, only 1s
+	Ack       int64  `protobuf:"varint,5,opt,name=ack,proto3" json:"ack,omitempty"`             // Sequence of the batch the client has finished with
+}
+
+func (x *LockstepRequest) Reset() {
+	*x = LockstepRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LockstepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockstepRequest) ProtoMessage() {}
+
+func (x *LockstepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockstepRequest.ProtoReflect.Descriptor instead.
+func (*LockstepRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LockstepRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *LockstepRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *LockstepRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *LockstepRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *LockstepRequest) GetAck() int64 {
+	if x != nil {
+		return x.Ack
+	}
+	return 0
+}
+
+type LockstepResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Klines   []*Kline `protobuf:"bytes,1,rep,name=klines,proto3" json:"klines,omitempty"`
+	Sequence int64    `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"` // Starts at 1, acknowledge it to receive the next batch, the done batch needs no ack
+	Done     bool     `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`         // Last batch of the range
+}
+
+func (x *LockstepResponse) Reset() {
+	*x = LockstepResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LockstepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockstepResponse) ProtoMessage() {}
+
+func (x *LockstepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockstepResponse.ProtoReflect.Descriptor instead.
+func (*LockstepResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LockstepResponse) GetKlines() []*Kline {
+	if x != nil {
+		return x.Klines
+	}
+	return nil
+}
+
+func (x *LockstepResponse) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *LockstepResponse) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+type KlineResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kline  *Kline  `protobuf:"bytes,1,opt,name=kline,proto3" json:"kline,omitempty"`
+	Spread *Spread `protobuf:"bytes,2,opt,name=spread,proto3" json:"spread,omitempty"` // Set by ReadHistoricalKline with withSpread when the second is known
+}
+
+func (x *KlineResponse) Reset() {
+	*x = KlineResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KlineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KlineResponse) ProtoMessage() {}
+
+func (x *KlineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KlineResponse.ProtoReflect.Descriptor instead.
+func (*KlineResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *KlineResponse) GetKline() *Kline {
+	if x != nil {
+		return x.Kline
+	}
+	return nil
+}
+
+func (x *KlineResponse) GetSpread() *Spread {
+	if x != nil {
+		return x.Spread
+	}
+	return nil
+}
+
+type IndicatorSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // sma, ema, rsi, vwap, atr or bbands
+	// sma, ema, rsi and atr take [period], vwap [period] or [] to reset every UTC day,
+	// bbands [period, stddevs], missing params use common defaults
+	Params []float64 `protobuf:"fixed64,2,rep,packed,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *IndicatorSpec) Reset() {
+	*x = IndicatorSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndicatorSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndicatorSpec) ProtoMessage() {}
+
+func (x *IndicatorSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndicatorSpec.ProtoReflect.Descriptor instead.
+func (*IndicatorSpec) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *IndicatorSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IndicatorSpec) GetParams() []float64 {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type SubscribeIndicatorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol     string           `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Interval   string           `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"` // 1s, 1m, 5m, 15m, 1h or 1d, empty means 1s
+	Indicators []*IndicatorSpec `protobuf:"bytes,3,rep,name=indicators,proto3" json:"indicators,omitempty"`
+	Warmup     int64            `protobuf:"varint,4,opt,name=warmup,proto3" json:"warmup,omitempty"` // Closed bars fed to the indicators before streaming, 0 takes what they need
+}
+
+func (x *SubscribeIndicatorRequest) Reset() {
+	*x = SubscribeIndicatorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeIndicatorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeIndicatorRequest) ProtoMessage() {}
+
+func (x *SubscribeIndicatorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeIndicatorRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeIndicatorRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SubscribeIndicatorRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SubscribeIndicatorRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *SubscribeIndicatorRequest) GetIndicators() []*IndicatorSpec {
+	if x != nil {
+		return x.Indicators
+	}
+	return nil
+}
+
+func (x *SubscribeIndicatorRequest) GetWarmup() int64 {
+	if x != nil {
+		return x.Warmup
+	}
+	return 0
+}
+
+type IndicatorValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string    `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                // Name and params, e.g. bbands(20,2)
+	Values []float64 `protobuf:"fixed64,2,rep,packed,name=values,proto3" json:"values,omitempty"` // bbands is [middle, upper, lower], the others a single value
+	Ready  bool      `protobuf:"varint,3,opt,name=ready,proto3" json:"ready,omitempty"`           // False until the indicator has seen enough bars
+}
+
+func (x *IndicatorValue) Reset() {
+	*x = IndicatorValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndicatorValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndicatorValue) ProtoMessage() {}
+
+func (x *IndicatorValue) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndicatorValue.ProtoReflect.Descriptor instead.
+func (*IndicatorValue) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *IndicatorValue) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IndicatorValue) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *IndicatorValue) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+type IndicatorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kline      *Kline            `protobuf:"bytes,1,opt,name=kline,proto3" json:"kline,omitempty"`
+	Indicators []*IndicatorValue `protobuf:"bytes,2,rep,name=indicators,proto3" json:"indicators,omitempty"` // In the order of the request
+}
+
+func (x *IndicatorResponse) Reset() {
+	*x = IndicatorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndicatorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndicatorResponse) ProtoMessage() {}
+
+func (x *IndicatorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndicatorResponse.ProtoReflect.Descriptor instead.
+func (*IndicatorResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *IndicatorResponse) GetKline() *Kline {
+	if x != nil {
+		return x.Kline
+	}
+	return nil
+}
+
+func (x *IndicatorResponse) GetIndicators() []*IndicatorValue {
+	if x != nil {
+		return x.Indicators
+	}
+	return nil
+}
+
+type Trade struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // Aggregate trade id, or the trade id when the server ingests individual trades
+	Price        float64 `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity     float64 `protobuf:"fixed64,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	FirstTradeId int64   `protobuf:"varint,4,opt,name=firstTradeId,proto3" json:"firstTradeId,omitempty"`
+	LastTradeId  int64   `protobuf:"varint,5,opt,name=lastTradeId,proto3" json:"lastTradeId,omitempty"`
+	Time         int64   `protobuf:"varint,6,opt,name=time,proto3" json:"time,omitempty"`
+	IsBuyerMaker bool    `protobuf:"varint,7,opt,name=isBuyerMaker,proto3" json:"isBuyerMaker,omitempty"`
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Trade) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Trade) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Trade) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Trade) GetFirstTradeId() int64 {
+	if x != nil {
+		return x.FirstTradeId
+	}
+	return 0
+}
+
+func (x *Trade) GetLastTradeId() int64 {
+	if x != nil {
+		return x.LastTradeId
+	}
+	return 0
+}
+
+func (x *Trade) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Trade) GetIsBuyerMaker() bool {
+	if x != nil {
+		return x.IsBuyerMaker
+	}
+	return false
+}
+
+type SubscribeTradesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+}
+
+func (x *SubscribeTradesRequest) Reset() {
+	*x = SubscribeTradesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeTradesRequest) ProtoMessage() {}
+
+func (x *SubscribeTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeTradesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeTradesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SubscribeTradesRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type ReadTradesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Start  int64  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End    int64  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`       // 0 reads up to the latest trade
+	FromId int64  `protobuf:"varint,4,opt,name=fromId,proto3" json:"fromId,omitempty"` // Resume from this trade id instead of the oldest one in the window
+}
+
+func (x *ReadTradesRequest) Reset() {
+	*x = ReadTradesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadTradesRequest) ProtoMessage() {}
+
+func (x *ReadTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadTradesRequest.ProtoReflect.Descriptor instead.
+func (*ReadTradesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReadTradesRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ReadTradesRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ReadTradesRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *ReadTradesRequest) GetFromId() int64 {
+	if x != nil {
+		return x.FromId
+	}
+	return 0
+}
+
+type TradeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Trade *Trade `protobuf:"bytes,1,opt,name=trade,proto3" json:"trade,omitempty"`
+}
+
+func (x *TradeResponse) Reset() {
+	*x = TradeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TradeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeResponse) ProtoMessage() {}
+
+func (x *TradeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeResponse.ProtoReflect.Descriptor instead.
+func (*TradeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TradeResponse) GetTrade() *Trade {
+	if x != nil {
+		return x.Trade
+	}
+	return nil
+}
+
+type PriceLevel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price    float64 `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity float64 `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *PriceLevel) Reset() {
+	*x = PriceLevel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriceLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceLevel) ProtoMessage() {}
+
+func (x *PriceLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceLevel.ProtoReflect.Descriptor instead.
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PriceLevel) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PriceLevel) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type OrderBookRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Depth  int32  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"` // Levels per side, 0 means 20, at most order_book.limit
+}
+
+func (x *OrderBookRequest) Reset() {
+	*x = OrderBookRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBookRequest) ProtoMessage() {}
+
+func (x *OrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBookRequest.ProtoReflect.Descriptor instead.
+func (*OrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *OrderBookRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OrderBookRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type SubscribeOrderBookRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol   string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Depth    int32  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`       // Levels per side, 0 means 20, at most order_book.limit
+	Throttle int64  `protobuf:"varint,3,opt,name=throttle,proto3" json:"throttle,omitempty"` // Minimum milliseconds between two books, 0 sends after every update
+}
+
+func (x *SubscribeOrderBookRequest) Reset() {
+	*x = SubscribeOrderBookRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeOrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeOrderBookRequest) ProtoMessage() {}
+
+func (x *SubscribeOrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeOrderBookRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeOrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SubscribeOrderBookRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SubscribeOrderBookRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *SubscribeOrderBookRequest) GetThrottle() int64 {
+	if x != nil {
+		return x.Throttle
+	}
+	return 0
+}
+
+type OrderBookResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LastUpdateId int64         `protobuf:"varint,1,opt,name=lastUpdateId,proto3" json:"lastUpdateId,omitempty"`
+	Time         int64         `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"` // Event time of the last update applied
+	Bids         []*PriceLevel `protobuf:"bytes,3,rep,name=bids,proto3" json:"bids,omitempty"`  // Best first
+	Asks         []*PriceLevel `protobuf:"bytes,4,rep,name=asks,proto3" json:"asks,omitempty"`  // Best first
+}
+
+func (x *OrderBookResponse) Reset() {
+	*x = OrderBookResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBookResponse) ProtoMessage() {}
+
+func (x *OrderBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBookResponse.ProtoReflect.Descriptor instead.
+func (*OrderBookResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *OrderBookResponse) GetLastUpdateId() int64 {
+	if x != nil {
+		return x.LastUpdateId
+	}
+	return 0
+}
+
+func (x *OrderBookResponse) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *OrderBookResponse) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *OrderBookResponse) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+type BookTicker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UpdateId    int64   `protobuf:"varint,1,opt,name=updateId,proto3" json:"updateId,omitempty"`
+	Time        int64   `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"` // Receive time
+	BidPrice    float64 `protobuf:"fixed64,3,opt,name=bidPrice,proto3" json:"bidPrice,omitempty"`
+	BidQuantity float64 `protobuf:"fixed64,4,opt,name=bidQuantity,proto3" json:"bidQuantity,omitempty"`
+	AskPrice    float64 `protobuf:"fixed64,5,opt,name=askPrice,proto3" json:"askPrice,omitempty"`
+	AskQuantity float64 `protobuf:"fixed64,6,opt,name=askQuantity,proto3" json:"askQuantity,omitempty"`
+}
+
+func (x *BookTicker) Reset() {
+	*x = BookTicker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookTicker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookTicker) ProtoMessage() {}
+
+func (x *BookTicker) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookTicker.ProtoReflect.Descriptor instead.
+func (*BookTicker) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *BookTicker) GetUpdateId() int64 {
+	if x != nil {
+		return x.UpdateId
+	}
+	return 0
+}
+
+func (x *BookTicker) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *BookTicker) GetBidPrice() float64 {
+	if x != nil {
+		return x.BidPrice
+	}
+	return 0
+}
+
+func (x *BookTicker) GetBidQuantity() float64 {
+	if x != nil {
+		return x.BidQuantity
+	}
+	return 0
+}
+
+func (x *BookTicker) GetAskPrice() float64 {
+	if x != nil {
+		return x.AskPrice
+	}
+	return 0
+}
+
+func (x *BookTicker) GetAskQuantity() float64 {
+	if x != nil {
+		return x.AskQuantity
+	}
+	return 0
+}
+
+type SubscribeBookTickerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+}
+
+func (x *SubscribeBookTickerRequest) Reset() {
+	*x = SubscribeBookTickerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeBookTickerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeBookTickerRequest) ProtoMessage() {}
+
+func (x *SubscribeBookTickerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeBookTickerRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeBookTickerRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SubscribeBookTickerRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type BookTickerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BookTicker *BookTicker `protobuf:"bytes,1,opt,name=bookTicker,proto3" json:"bookTicker,omitempty"`
+}
+
+func (x *BookTickerResponse) Reset() {
+	*x = BookTickerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookTickerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookTickerResponse) ProtoMessage() {}
+
+func (x *BookTickerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookTickerResponse.ProtoReflect.Descriptor instead.
+func (*BookTickerResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BookTickerResponse) GetBookTicker() *BookTicker {
+	if x != nil {
+		return x.BookTicker
+	}
+	return nil
+}
+
+type Spread struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OpenTime   int64   `protobuf:"varint,1,opt,name=openTime,proto3" json:"openTime,omitempty"` // Open time of the 1s kline it is aligned with
+	Bid        float64 `protobuf:"fixed64,2,opt,name=bid,proto3" json:"bid,omitempty"`          // Best bid at the end of the second
+	Ask        float64 `protobuf:"fixed64,3,opt,name=ask,proto3" json:"ask,omitempty"`
+	Mid        float64 `protobuf:"fixed64,4,opt,name=mid,proto3" json:"mid,omitempty"`
+	Spread     float64 `protobuf:"fixed64,5,opt,name=spread,proto3" json:"spread,omitempty"`
+	MeanSpread float64 `protobuf:"fixed64,6,opt,name=meanSpread,proto3" json:"meanSpread,omitempty"` // Mean over the updates within the second
+	Updates    int64   `protobuf:"varint,7,opt,name=updates,proto3" json:"updates,omitempty"`        // 0 when the book ticker before the second was carried over
+}
+
+func (x *Spread) Reset() {
+	*x = Spread{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Spread) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Spread) ProtoMessage() {}
+
+func (x *Spread) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Spread.ProtoReflect.Descriptor instead.
+func (*Spread) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Spread) GetOpenTime() int64 {
+	if x != nil {
+		return x.OpenTime
+	}
+	return 0
+}
+
+func (x *Spread) GetBid() float64 {
+	if x != nil {
+		return x.Bid
+	}
+	return 0
+}
+
+func (x *Spread) GetAsk() float64 {
+	if x != nil {
+		return x.Ask
+	}
+	return 0
+}
+
+func (x *Spread) GetMid() float64 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *Spread) GetSpread() float64 {
+	if x != nil {
+		return x.Spread
+	}
+	return 0
+}
+
+func (x *Spread) GetMeanSpread() float64 {
+	if x != nil {
+		return x.MeanSpread
+	}
+	return 0
+}
+
+func (x *Spread) GetUpdates() int64 {
+	if x != nil {
+		return x.Updates
+	}
+	return 0
+}
+
+type ReadFuturesKlineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol    string    `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	PriceType PriceType `protobuf:"varint,2,opt,name=priceType,proto3,enum=feed.PriceType" json:"priceType,omitempty"`
+	Start     int64     `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End       int64     `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`          // 0 reads up to the latest kline
+	Interval  string    `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"` // 1m, 5m, 15m, 1h or 1d, empty means 1m
+}
+
+func (x *ReadFuturesKlineRequest) Reset() {
+	*x = ReadFuturesKlineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadFuturesKlineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadFuturesKlineRequest) ProtoMessage() {}
+
+func (x *ReadFuturesKlineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadFuturesKlineRequest.ProtoReflect.Descriptor instead.
+func (*ReadFuturesKlineRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReadFuturesKlineRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ReadFuturesKlineRequest) GetPriceType() PriceType {
+	if x != nil {
+		return x.PriceType
+	}
+	return PriceType_CONTRACT_PRICE
+}
+
+func (x *ReadFuturesKlineRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ReadFuturesKlineRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *ReadFuturesKlineRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+type FundingRate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FundingTime int64   `protobuf:"varint,1,opt,name=fundingTime,proto3" json:"fundingTime,omitempty"`
+	Rate        float64 `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	MarkPrice   float64 `protobuf:"fixed64,3,opt,name=markPrice,proto3" json:"markPrice,omitempty"`
+}
+
+func (x *FundingRate) Reset() {
+	*x = FundingRate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FundingRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FundingRate) ProtoMessage() {}
+
+func (x *FundingRate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FundingRate.ProtoReflect.Descriptor instead.
+func (*FundingRate) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *FundingRate) GetFundingTime() int64 {
+	if x != nil {
+		return x.FundingTime
+	}
+	return 0
+}
+
+func (x *FundingRate) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *FundingRate) GetMarkPrice() float64 {
+	if x != nil {
+		return x.MarkPrice
+	}
+	return 0
+}
+
+type ReadFundingRateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Start  int64  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End    int64  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"` // 0 reads up to the latest funding
+}
+
+func (x *ReadFundingRateRequest) Reset() {
+	*x = ReadFundingRateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadFundingRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadFundingRateRequest) ProtoMessage() {}
+
+func (x *ReadFundingRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadFundingRateRequest.ProtoReflect.Descriptor instead.
+func (*ReadFundingRateRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ReadFundingRateRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ReadFundingRateRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ReadFundingRateRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type FundingRateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FundingRate *FundingRate `protobuf:"bytes,1,opt,name=fundingRate,proto3" json:"fundingRate,omitempty"`
+}
+
+func (x *FundingRateResponse) Reset() {
+	*x = FundingRateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FundingRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FundingRateResponse) ProtoMessage() {}
+
+func (x *FundingRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FundingRateResponse.ProtoReflect.Descriptor instead.
+func (*FundingRateResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *FundingRateResponse) GetFundingRate() *FundingRate {
+	if x != nil {
+		return x.FundingRate
+	}
+	return nil
+}
+
+type MarkPrice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time                 int64   `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	MarkPrice            float64 `protobuf:"fixed64,2,opt,name=markPrice,proto3" json:"markPrice,omitempty"`
+	IndexPrice           float64 `protobuf:"fixed64,3,opt,name=indexPrice,proto3" json:"indexPrice,omitempty"`
+	EstimatedSettlePrice float64 `protobuf:"fixed64,4,opt,name=estimatedSettlePrice,proto3" json:"estimatedSettlePrice,omitempty"`
+	FundingRate          float64 `protobuf:"fixed64,5,opt,name=fundingRate,proto3" json:"fundingRate,omitempty"` // Rate of the upcoming funding
+	NextFundingTime      int64   `protobuf:"varint,6,opt,name=nextFundingTime,proto3" json:"nextFundingTime,omitempty"`
+}
+
+func (x *MarkPrice) Reset() {
+	*x = MarkPrice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MarkPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkPrice) ProtoMessage() {}
+
+func (x *MarkPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkPrice.ProtoReflect.Descriptor instead.
+func (*MarkPrice) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *MarkPrice) GetTime() int64 {
 	if x != nil {
-		return x.Kline
+		return x.Time
+	}
+	return 0
+}
+
+func (x *MarkPrice) GetMarkPrice() float64 {
+	if x != nil {
+		return x.MarkPrice
+	}
+	return 0
+}
+
+func (x *MarkPrice) GetIndexPrice() float64 {
+	if x != nil {
+		return x.IndexPrice
+	}
+	return 0
+}
+
+func (x *MarkPrice) GetEstimatedSettlePrice() float64 {
+	if x != nil {
+		return x.EstimatedSettlePrice
+	}
+	return 0
+}
+
+func (x *MarkPrice) GetFundingRate() float64 {
+	if x != nil {
+		return x.FundingRate
+	}
+	return 0
+}
+
+func (x *MarkPrice) GetNextFundingTime() int64 {
+	if x != nil {
+		return x.NextFundingTime
+	}
+	return 0
+}
+
+type SubscribeMarkPriceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+}
+
+func (x *SubscribeMarkPriceRequest) Reset() {
+	*x = SubscribeMarkPriceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeMarkPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeMarkPriceRequest) ProtoMessage() {}
+
+func (x *SubscribeMarkPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeMarkPriceRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeMarkPriceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SubscribeMarkPriceRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type MarkPriceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MarkPrice *MarkPrice `protobuf:"bytes,1,opt,name=markPrice,proto3" json:"markPrice,omitempty"`
+}
+
+func (x *MarkPriceResponse) Reset() {
+	*x = MarkPriceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_feed_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MarkPriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkPriceResponse) ProtoMessage() {}
+
+func (x *MarkPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_feed_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkPriceResponse.ProtoReflect.Descriptor instead.
+func (*MarkPriceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_feed_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *MarkPriceResponse) GetMarkPrice() *MarkPrice {
+	if x != nil {
+		return x.MarkPrice
 	}
 	return nil
 }
@@ -490,83 +3272,462 @@ var File_api_proto_feed_proto protoreflect.FileDescriptor
 
 var file_api_proto_feed_proto_rawDesc = []byte{
 	0x0a, 0x14, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x66, 0x65, 0x65, 0x64,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x66, 0x65, 0x65, 0x64, 0x1a, 0x1b, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d,
-	0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe7, 0x02, 0x0a, 0x05, 0x4b, 0x6c,
-	0x69, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6f,
-	0x70, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x77, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x6f,
-	0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65,
-	0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x73,
-	0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x41, 0x73,
-	0x73, 0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x10, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x64, 0x65, 0x4e, 0x75, 0x6d, 0x18, 0x09, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x72, 0x61, 0x64, 0x65, 0x4e, 0x75, 0x6d, 0x12, 0x38, 0x0a,
-	0x17, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x42, 0x75, 0x79, 0x42, 0x61, 0x73, 0x65, 0x41, 0x73, 0x73,
-	0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x17,
-	0x74, 0x61, 0x6b, 0x65, 0x72, 0x42, 0x75, 0x79, 0x42, 0x61, 0x73, 0x65, 0x41, 0x73, 0x73, 0x65,
-	0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x74, 0x61, 0x6b, 0x65, 0x72,
-	0x42, 0x75, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x56, 0x6f, 0x6c,
-	0x75, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18, 0x74, 0x61, 0x6b, 0x65, 0x72,
-	0x42, 0x75, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x56, 0x6f, 0x6c,
-	0x75, 0x6d, 0x65, 0x22, 0x3a, 0x0a, 0x10, 0x52, 0x65, 0x61, 0x64, 0x4b, 0x6c, 0x69, 0x6e, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a,
-	0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22,
-	0x90, 0x01, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10,
-	0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64,
-	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12,
-	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69,
-	0x7a, 0x65, 0x22, 0x40, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06,
-	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6c, 0x65,
-	0x6e, 0x67, 0x74, 0x68, 0x22, 0x36, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x75,
-	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52,
-	0x0b, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73, 0x22, 0x32, 0x0a, 0x0d,
-	0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a,
-	0x05, 0x6b, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x66,
-	0x65, 0x65, 0x64, 0x2e, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x05, 0x6b, 0x6c, 0x69, 0x6e, 0x65,
-	0x2a, 0x47, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52,
-	0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12,
-	0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x4c, 0x49, 0x5a, 0x49, 0x4e, 0x47, 0x10,
-	0x02, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07,
-	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x04, 0x32, 0xc6, 0x02, 0x0a, 0x04, 0x46, 0x65,
-	0x65, 0x64, 0x12, 0x39, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a,
-	0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x14, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53,
-	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x18, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
-	0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0e, 0x53,
-	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x4b, 0x6c, 0x69,
-	0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x13,
-	0x52, 0x65, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x4b, 0x6c,
-	0x69, 0x6e, 0x65, 0x12, 0x16, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x4b,
-	0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x66, 0x65,
-	0x65, 0x64, 0x2e, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x30, 0x01, 0x42, 0x15, 0x5a, 0x13, 0x2e, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x65, 0x6e, 0x2f,
-	0x66, 0x65, 0x65, 0x64, 0x3b, 0x66, 0x65, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x66, 0x65, 0x65, 0x64, 0x22, 0x99, 0x03, 0x0a,
+	0x05, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x6e, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x6e, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f,
+	0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x63, 0x6c, 0x6f,
+	0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6c,
+	0x6f, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63,
+	0x6c, 0x6f, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x71, 0x75, 0x6f, 0x74,
+	0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x10, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x64, 0x65, 0x4e, 0x75, 0x6d,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x72, 0x61, 0x64, 0x65, 0x4e, 0x75, 0x6d,
+	0x12, 0x38, 0x0a, 0x17, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x42, 0x75, 0x79, 0x42, 0x61, 0x73, 0x65,
+	0x41, 0x73, 0x73, 0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x17, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x42, 0x75, 0x79, 0x42, 0x61, 0x73, 0x65, 0x41,
+	0x73, 0x73, 0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x74, 0x61,
+	0x6b, 0x65, 0x72, 0x42, 0x75, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18, 0x74, 0x61,
+	0x6b, 0x65, 0x72, 0x42, 0x75, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x73, 0x46, 0x69, 0x6e, 0x61,
+	0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x46, 0x69, 0x6e, 0x61, 0x6c,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0x27, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x22, 0x27, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0x2b, 0x0a, 0x11, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0x9e, 0x02, 0x0a, 0x15, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12,
+	0x22, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x4f, 0x70, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x4f, 0x70, 0x65, 0x6e, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x73, 0x12, 0x27, 0x0a, 0x07, 0x62, 0x61, 0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x42, 0x61, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x07, 0x62, 0x61, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0xd5, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x61,
+	0x64, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a,
+	0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x27, 0x0a, 0x07, 0x62, 0x61, 0x72,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x66, 0x65, 0x65,
+	0x64, 0x2e, 0x42, 0x61, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x62, 0x61, 0x72, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x69, 0x74, 0x68, 0x53, 0x70, 0x72, 0x65, 0x61, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x77, 0x69, 0x74, 0x68, 0x53, 0x70, 0x72, 0x65, 0x61, 0x64,
+	0x22, 0xa4, 0x01, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x61, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x67, 0x61, 0x70, 0x73, 0x22, 0x40, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x62, 0x0a, 0x12, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72,
+	0x73, 0x12, 0x2a, 0x0a, 0x05, 0x69, 0x6e, 0x66, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x69, 0x6e, 0x66, 0x6f, 0x73, 0x22, 0xf4, 0x01,
+	0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x71, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x22, 0x53, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x22, 0x24, 0x0a, 0x12, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0xa1, 0x01, 0x0a, 0x13, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x73, 0x22, 0x28, 0x0a, 0x16, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x4a, 0x0a,
+	0x17, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x65, 0x65,
+	0x64, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x16, 0x50, 0x6c,
+	0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x50, 0x6c, 0x61, 0x79,
+	0x62, 0x61, 0x63, 0x6b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x65, 0x6b,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x65, 0x6b,
+	0x54, 0x69, 0x6d, 0x65, 0x22, 0x46, 0x0a, 0x17, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0x93, 0x02, 0x0a,
+	0x0d, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73,
+	0x70, 0x65, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x65, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x66, 0x65, 0x65,
+	0x64, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x73, 0x22, 0x85, 0x01, 0x0a, 0x0f, 0x4c, 0x6f, 0x63, 0x6b, 0x73, 0x74, 0x65, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x63, 0x6b, 0x22, 0x67, 0x0a, 0x10, 0x4c, 0x6f,
+	0x63, 0x6b, 0x73, 0x74, 0x65, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23,
+	0x0a, 0x06, 0x6b, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x06, 0x6b, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64,
+	0x6f, 0x6e, 0x65, 0x22, 0x58, 0x0a, 0x0d, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x6b, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x4b, 0x6c, 0x69, 0x6e, 0x65,
+	0x52, 0x05, 0x6b, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x73, 0x70, 0x72, 0x65, 0x61,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x53,
+	0x70, 0x72, 0x65, 0x61, 0x64, 0x52, 0x06, 0x73, 0x70, 0x72, 0x65, 0x61, 0x64, 0x22, 0x3b, 0x0a,
+	0x0d, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x01, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x19, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x33, 0x0a, 0x0a,
+	0x69, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f,
+	0x72, 0x53, 0x70, 0x65, 0x63, 0x52, 0x0a, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x22, 0x50, 0x0a, 0x0e, 0x49, 0x6e, 0x64,
+	0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x22, 0x6c, 0x0a, 0x11, 0x49,
+	0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x21, 0x0a, 0x05, 0x6b, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x05, 0x6b, 0x6c,
+	0x69, 0x6e, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x49,
+	0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a, 0x69,
+	0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0xc7, 0x01, 0x0a, 0x05, 0x54, 0x72,
+	0x61, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x71, 0x75, 0x61,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x54, 0x72,
+	0x61, 0x64, 0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x54, 0x72, 0x61, 0x64, 0x65, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x61, 0x73,
+	0x74, 0x54, 0x72, 0x61, 0x64, 0x65, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x6c, 0x61, 0x73, 0x74, 0x54, 0x72, 0x61, 0x64, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x22, 0x0a, 0x0c, 0x69, 0x73, 0x42, 0x75, 0x79, 0x65, 0x72, 0x4d, 0x61, 0x6b, 0x65, 0x72, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x42, 0x75, 0x79, 0x65, 0x72, 0x4d, 0x61,
+	0x6b, 0x65, 0x72, 0x22, 0x30, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0x6b, 0x0a, 0x11, 0x52, 0x65, 0x61, 0x64, 0x54, 0x72, 0x61,
+	0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x72,
+	0x6f, 0x6d, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x72, 0x6f, 0x6d,
+	0x49, 0x64, 0x22, 0x32, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52,
+	0x05, 0x74, 0x72, 0x61, 0x64, 0x65, 0x22, 0x3e, 0x0a, 0x0a, 0x50, 0x72, 0x69, 0x63, 0x65, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x40, 0x0a, 0x10, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42,
+	0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x22, 0x65, 0x0a, 0x19, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x14, 0x0a,
+	0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65,
+	0x70, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x22,
+	0x97, 0x01, 0x0a, 0x11, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x61, 0x73,
+	0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x24, 0x0a,
+	0x04, 0x62, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x65,
+	0x65, 0x64, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x62,
+	0x69, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x04, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x52, 0x04, 0x61, 0x73, 0x6b, 0x73, 0x22, 0xb8, 0x01, 0x0a, 0x0a, 0x42, 0x6f,
+	0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x69, 0x64, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x62, 0x69, 0x64, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x69, 0x64, 0x51, 0x75, 0x61, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x62, 0x69, 0x64, 0x51, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x73, 0x6b, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x61, 0x73, 0x6b, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x73, 0x6b, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x61, 0x73, 0x6b, 0x51, 0x75, 0x61, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x22, 0x34, 0x0a, 0x1a, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x42, 0x6f, 0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0x46, 0x0a, 0x12, 0x42, 0x6f,
+	0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x30, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x42, 0x6f, 0x6f, 0x6b,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x0a, 0x62, 0x6f, 0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b,
+	0x65, 0x72, 0x22, 0xac, 0x01, 0x0a, 0x06, 0x53, 0x70, 0x72, 0x65, 0x61, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x6f, 0x70, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x6f, 0x70, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x62, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x61,
+	0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x61, 0x73, 0x6b, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x70, 0x72, 0x65, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x06, 0x73, 0x70, 0x72, 0x65, 0x61, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x61, 0x6e, 0x53,
+	0x70, 0x72, 0x65, 0x61, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x6d, 0x65, 0x61,
+	0x6e, 0x53, 0x70, 0x72, 0x65, 0x61, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x73, 0x22, 0xa4, 0x01, 0x0a, 0x17, 0x52, 0x65, 0x61, 0x64, 0x46, 0x75, 0x74, 0x75, 0x72, 0x65,
+	0x73, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x2d, 0x0a, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x61, 0x0a, 0x0b, 0x46, 0x75, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x52, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x75, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x66, 0x75,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x6d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x6d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0x58, 0x0a, 0x16, 0x52,
+	0x65, 0x61, 0x64, 0x46, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x4a, 0x0a, 0x13, 0x46, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x0b,
+	0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x46, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x52, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x61, 0x74,
+	0x65, 0x22, 0xdd, 0x01, 0x0a, 0x09, 0x4d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x32, 0x0a, 0x14, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65,
+	0x74, 0x74, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x14, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x74, 0x74, 0x6c, 0x65,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x52, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x66, 0x75, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x52, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x46,
+	0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x46, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d,
+	0x65, 0x22, 0x33, 0x0a, 0x19, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x61,
+	0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0x42, 0x0a, 0x11, 0x4d, 0x61, 0x72, 0x6b, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x6d,
+	0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52,
+	0x09, 0x6d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x2a, 0x56, 0x0a, 0x07, 0x42, 0x61,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x42, 0x41,
+	0x52, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x56, 0x4f, 0x4c, 0x55, 0x4d, 0x45, 0x5f, 0x42, 0x41,
+	0x52, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x4f, 0x4c, 0x4c, 0x41, 0x52, 0x5f, 0x42, 0x41,
+	0x52, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x49, 0x43, 0x4b, 0x5f, 0x42, 0x41, 0x52, 0x10,
+	0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x48, 0x45, 0x49, 0x4b, 0x49, 0x4e, 0x5f, 0x41, 0x53, 0x48, 0x49,
+	0x10, 0x04, 0x2a, 0x40, 0x0a, 0x09, 0x50, 0x72, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x50, 0x52, 0x49, 0x43,
+	0x45, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x50, 0x52, 0x49, 0x43,
+	0x45, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x5f, 0x50, 0x52, 0x49,
+	0x43, 0x45, 0x10, 0x02, 0x2a, 0x47, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b,
+	0x0a, 0x07, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f,
+	0x4b, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x4c, 0x49, 0x5a,
+	0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03,
+	0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x04, 0x2a, 0x74, 0x0a,
+	0x0e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x0a, 0x0e, 0x50, 0x4c, 0x41, 0x59, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x51, 0x55, 0x45, 0x52,
+	0x59, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x4c, 0x41, 0x59, 0x42, 0x41, 0x43, 0x4b, 0x5f,
+	0x50, 0x41, 0x55, 0x53, 0x45, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x4c, 0x41, 0x59, 0x42,
+	0x41, 0x43, 0x4b, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4d, 0x45, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d,
+	0x50, 0x4c, 0x41, 0x59, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x53, 0x45, 0x45, 0x4b, 0x10, 0x03, 0x12,
+	0x12, 0x0a, 0x0e, 0x50, 0x4c, 0x41, 0x59, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x53, 0x50, 0x45, 0x45,
+	0x44, 0x10, 0x04, 0x2a, 0x6a, 0x0a, 0x0e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x4c, 0x41, 0x59, 0x42, 0x41, 0x43,
+	0x4b, 0x5f, 0x4f, 0x50, 0x45, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x4c,
+	0x41, 0x59, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x50, 0x4c, 0x41, 0x59, 0x49, 0x4e, 0x47, 0x10, 0x01,
+	0x12, 0x15, 0x0a, 0x11, 0x50, 0x4c, 0x41, 0x59, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x46, 0x49, 0x4e,
+	0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x4c, 0x41, 0x59, 0x42,
+	0x41, 0x43, 0x4b, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x32,
+	0xb4, 0x0b, 0x0a, 0x04, 0x46, 0x65, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x13, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x66, 0x65, 0x65,
+	0x64, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x36, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x13, 0x2e,
+	0x66, 0x65, 0x65, 0x64, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x66, 0x65, 0x65, 0x64,
+	0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x14,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x66, 0x65, 0x65, 0x64, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1b, 0x2e, 0x66, 0x65, 0x65, 0x64,
+	0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x4b, 0x6c,
+	0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a,
+	0x13, 0x52, 0x65, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x4b,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x52, 0x65, 0x61, 0x64,
+	0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x66,
+	0x65, 0x65, 0x64, 0x2e, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x0c, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x6c, 0x61, 0x79, 0x62,
+	0x61, 0x63, 0x6b, 0x12, 0x19, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x50,
+	0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x4a, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61,
+	0x63, 0x6b, 0x12, 0x1c, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61,
+	0x63, 0x6b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4d, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63,
+	0x6b, 0x12, 0x1c, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63,
+	0x6b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e,
+	0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63,
+	0x6b, 0x12, 0x1c, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63,
+	0x6b, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42,
+	0x0a, 0x0d, 0x4c, 0x6f, 0x63, 0x6b, 0x73, 0x74, 0x65, 0x70, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x12,
+	0x15, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x73, 0x74, 0x65, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x4c, 0x6f,
+	0x63, 0x6b, 0x73, 0x74, 0x65, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01,
+	0x30, 0x01, 0x12, 0x50, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x49,
+	0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1f, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74,
+	0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x66, 0x65, 0x65, 0x64,
+	0x2e, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x46, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x54, 0x72, 0x61,
+	0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x46, 0x0a, 0x14,
+	0x52, 0x65, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x54, 0x72,
+	0x61, 0x64, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x52, 0x65, 0x61, 0x64,
+	0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x66, 0x65, 0x65, 0x64, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x16, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x66,
+	0x65, 0x65, 0x64, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x1f, 0x2e, 0x66, 0x65,
+	0x65, 0x64, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x66,
+	0x65, 0x65, 0x64, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x53, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x42, 0x6f, 0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x20,
+	0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x42,
+	0x6f, 0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x10,
+	0x52, 0x65, 0x61, 0x64, 0x46, 0x75, 0x74, 0x75, 0x72, 0x65, 0x73, 0x4b, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x1d, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x46, 0x75, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x13, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0f, 0x52, 0x65, 0x61, 0x64, 0x46, 0x75,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x66, 0x65, 0x65, 0x64,
+	0x2e, 0x52, 0x65, 0x61, 0x64, 0x46, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x46,
+	0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x4d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1f, 0x2e, 0x66, 0x65, 0x65,
+	0x64, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x66, 0x65,
+	0x65, 0x64, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x15, 0x5a, 0x13, 0x2e, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x67, 0x65, 0x6e, 0x2f, 0x66, 0x65, 0x65, 0x64, 0x3b, 0x66, 0x65, 0x65, 0x64, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -581,36 +3742,126 @@ func file_api_proto_feed_proto_rawDescGZIP() []byte {
 	return file_api_proto_feed_proto_rawDescData
 }
 
-var file_api_proto_feed_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_proto_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_proto_feed_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_api_proto_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_api_proto_feed_proto_goTypes = []interface{}{
-	(Status)(0),                // 0: feed.Status
-	(*Kline)(nil),              // 1: feed.Kline
-	(*ReadKlineRequest)(nil),   // 2: feed.ReadKlineRequest
-	(*StatusResponse)(nil),     // 3: feed.StatusResponse
-	(*ConfigResponse)(nil),     // 4: feed.ConfigResponse
-	(*SubscriberResponse)(nil), // 5: feed.SubscriberResponse
-	(*KlineResponse)(nil),      // 6: feed.KlineResponse
-	(*emptypb.Empty)(nil),      // 7: google.protobuf.Empty
+	(BarType)(0),                       // 0: feed.BarType
+	(PriceType)(0),                     // 1: feed.PriceType
+	(Status)(0),                        // 2: feed.Status
+	(PlaybackAction)(0),                // 3: feed.PlaybackAction
+	(PlaybackStatus)(0),                // 4: feed.PlaybackStatus
+	(*Kline)(nil),                      // 5: feed.Kline
+	(*ConfigRequest)(nil),              // 6: feed.ConfigRequest
+	(*StatusRequest)(nil),              // 7: feed.StatusRequest
+	(*SubscriberRequest)(nil),          // 8: feed.SubscriberRequest
+	(*SubscribeKlineRequest)(nil),      // 9: feed.SubscribeKlineRequest
+	(*ReadKlineRequest)(nil),           // 10: feed.ReadKlineRequest
+	(*StatusResponse)(nil),             // 11: feed.StatusResponse
+	(*ConfigResponse)(nil),             // 12: feed.ConfigResponse
+	(*SubscriberResponse)(nil),         // 13: feed.SubscriberResponse
+	(*SubscriberInfo)(nil),             // 14: feed.SubscriberInfo
+	(*DisconnectRequest)(nil),          // 15: feed.DisconnectRequest
+	(*DisconnectResponse)(nil),         // 16: feed.DisconnectResponse
+	(*OpenPlaybackRequest)(nil),        // 17: feed.OpenPlaybackRequest
+	(*PlaybackSessionRequest)(nil),     // 18: feed.PlaybackSessionRequest
+	(*PlaybackSessionResponse)(nil),    // 19: feed.PlaybackSessionResponse
+	(*PlaybackControlRequest)(nil),     // 20: feed.PlaybackControlRequest
+	(*PlaybackControlResponse)(nil),    // 21: feed.PlaybackControlResponse
+	(*PlaybackState)(nil),              // 22: feed.PlaybackState
+	(*LockstepRequest)(nil),            // 23: feed.LockstepRequest
+	(*LockstepResponse)(nil),           // 24: feed.LockstepResponse
+	(*KlineResponse)(nil),              // 25: feed.KlineResponse
+	(*IndicatorSpec)(nil),              // 26: feed.IndicatorSpec
+	(*SubscribeIndicatorRequest)(nil),  // 27: feed.SubscribeIndicatorRequest
+	(*IndicatorValue)(nil),             // 28: feed.IndicatorValue
+	(*IndicatorResponse)(nil),          // 29: feed.IndicatorResponse
+	(*Trade)(nil),                      // 30: feed.Trade
+	(*SubscribeTradesRequest)(nil),     // 31: feed.SubscribeTradesRequest
+	(*ReadTradesRequest)(nil),          // 32: feed.ReadTradesRequest
+	(*TradeResponse)(nil),              // 33: feed.TradeResponse
+	(*PriceLevel)(nil),                 // 34: feed.PriceLevel
+	(*OrderBookRequest)(nil),           // 35: feed.OrderBookRequest
+	(*SubscribeOrderBookRequest)(nil),  // 36: feed.SubscribeOrderBookRequest
+	(*OrderBookResponse)(nil),          // 37: feed.OrderBookResponse
+	(*BookTicker)(nil),                 // 38: feed.BookTicker
+	(*SubscribeBookTickerRequest)(nil), // 39: feed.SubscribeBookTickerRequest
+	(*BookTickerResponse)(nil),         // 40: feed.BookTickerResponse
+	(*Spread)(nil),                     // 41: feed.Spread
+	(*ReadFuturesKlineRequest)(nil),    // 42: feed.ReadFuturesKlineRequest
+	(*FundingRate)(nil),                // 43: feed.FundingRate
+	(*ReadFundingRateRequest)(nil),     // 44: feed.ReadFundingRateRequest
+	(*FundingRateResponse)(nil),        // 45: feed.FundingRateResponse
+	(*MarkPrice)(nil),                  // 46: feed.MarkPrice
+	(*SubscribeMarkPriceRequest)(nil),  // 47: feed.SubscribeMarkPriceRequest
+	(*MarkPriceResponse)(nil),          // 48: feed.MarkPriceResponse
 }
 var file_api_proto_feed_proto_depIdxs = []int32{
-	0, // 0: feed.StatusResponse.status:type_name -> feed.Status
-	1, // 1: feed.KlineResponse.kline:type_name -> feed.Kline
-	7, // 2: feed.Feed.GetConfig:input_type -> google.protobuf.Empty
-	7, // 3: feed.Feed.GetStatus:input_type -> google.protobuf.Empty
-	7, // 4: feed.Feed.GetSubscriber:input_type -> google.protobuf.Empty
-	7, // 5: feed.Feed.SubscribeKline:input_type -> google.protobuf.Empty
-	2, // 6: feed.Feed.ReadHistoricalKline:input_type -> feed.ReadKlineRequest
-	4, // 7: feed.Feed.GetConfig:output_type -> feed.ConfigResponse
-	3, // 8: feed.Feed.GetStatus:output_type -> feed.StatusResponse
-	5, // 9: feed.Feed.GetSubscriber:output_type -> feed.SubscriberResponse
-	6, // 10: feed.Feed.SubscribeKline:output_type -> feed.KlineResponse
-	6, // 11: feed.Feed.ReadHistoricalKline:output_type -> feed.KlineResponse
-	7, // [7:12] is the sub-list for method output_type
-	2, // [2:7] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: feed.SubscribeKlineRequest.barType:type_name -> feed.BarType
+	0,  // 1: feed.ReadKlineRequest.barType:type_name -> feed.BarType
+	2,  // 2: feed.StatusResponse.status:type_name -> feed.Status
+	14, // 3: feed.SubscriberResponse.infos:type_name -> feed.SubscriberInfo
+	22, // 4: feed.PlaybackSessionResponse.sessions:type_name -> feed.PlaybackState
+	3,  // 5: feed.PlaybackControlRequest.action:type_name -> feed.PlaybackAction
+	22, // 6: feed.PlaybackControlResponse.states:type_name -> feed.PlaybackState
+	4,  // 7: feed.PlaybackState.status:type_name -> feed.PlaybackStatus
+	5,  // 8: feed.LockstepResponse.klines:type_name -> feed.Kline
+	5,  // 9: feed.KlineResponse.kline:type_name -> feed.Kline
+	41, // 10: feed.KlineResponse.spread:type_name -> feed.Spread
+	26, // 11: feed.SubscribeIndicatorRequest.indicators:type_name -> feed.IndicatorSpec
+	5,  // 12: feed.IndicatorResponse.kline:type_name -> feed.Kline
+	28, // 13: feed.IndicatorResponse.indicators:type_name -> feed.IndicatorValue
+	30, // 14: feed.TradeResponse.trade:type_name -> feed.Trade
+	34, // 15: feed.OrderBookResponse.bids:type_name -> feed.PriceLevel
+	34, // 16: feed.OrderBookResponse.asks:type_name -> feed.PriceLevel
+	38, // 17: feed.BookTickerResponse.bookTicker:type_name -> feed.BookTicker
+	1,  // 18: feed.ReadFuturesKlineRequest.priceType:type_name -> feed.PriceType
+	43, // 19: feed.FundingRateResponse.fundingRate:type_name -> feed.FundingRate
+	46, // 20: feed.MarkPriceResponse.markPrice:type_name -> feed.MarkPrice
+	6,  // 21: feed.Feed.GetConfig:input_type -> feed.ConfigRequest
+	7,  // 22: feed.Feed.GetStatus:input_type -> feed.StatusRequest
+	8,  // 23: feed.Feed.GetSubscriber:input_type -> feed.SubscriberRequest
+	15, // 24: feed.Feed.DisconnectSubscriber:input_type -> feed.DisconnectRequest
+	9,  // 25: feed.Feed.SubscribeKline:input_type -> feed.SubscribeKlineRequest
+	10, // 26: feed.Feed.ReadHistoricalKline:input_type -> feed.ReadKlineRequest
+	17, // 27: feed.Feed.OpenPlayback:input_type -> feed.OpenPlaybackRequest
+	18, // 28: feed.Feed.GetPlayback:input_type -> feed.PlaybackSessionRequest
+	18, // 29: feed.Feed.CancelPlayback:input_type -> feed.PlaybackSessionRequest
+	20, // 30: feed.Feed.ControlPlayback:input_type -> feed.PlaybackControlRequest
+	23, // 31: feed.Feed.LockstepKline:input_type -> feed.LockstepRequest
+	27, // 32: feed.Feed.SubscribeIndicator:input_type -> feed.SubscribeIndicatorRequest
+	31, // 33: feed.Feed.SubscribeTrades:input_type -> feed.SubscribeTradesRequest
+	32, // 34: feed.Feed.ReadHistoricalTrades:input_type -> feed.ReadTradesRequest
+	35, // 35: feed.Feed.GetOrderBook:input_type -> feed.OrderBookRequest
+	36, // 36: feed.Feed.SubscribeOrderBook:input_type -> feed.SubscribeOrderBookRequest
+	39, // 37: feed.Feed.SubscribeBookTicker:input_type -> feed.SubscribeBookTickerRequest
+	42, // 38: feed.Feed.ReadFuturesKline:input_type -> feed.ReadFuturesKlineRequest
+	44, // 39: feed.Feed.ReadFundingRate:input_type -> feed.ReadFundingRateRequest
+	47, // 40: feed.Feed.SubscribeMarkPrice:input_type -> feed.SubscribeMarkPriceRequest
+	12, // 41: feed.Feed.GetConfig:output_type -> feed.ConfigResponse
+	11, // 42: feed.Feed.GetStatus:output_type -> feed.StatusResponse
+	13, // 43: feed.Feed.GetSubscriber:output_type -> feed.SubscriberResponse
+	16, // 44: feed.Feed.DisconnectSubscriber:output_type -> feed.DisconnectResponse
+	25, // 45: feed.Feed.SubscribeKline:output_type -> feed.KlineResponse
+	25, // 46: feed.Feed.ReadHistoricalKline:output_type -> feed.KlineResponse
+	22, // 47: feed.Feed.OpenPlayback:output_type -> feed.PlaybackState
+	19, // 48: feed.Feed.GetPlayback:output_type -> feed.PlaybackSessionResponse
+	19, // 49: feed.Feed.CancelPlayback:output_type -> feed.PlaybackSessionResponse
+	21, // 50: feed.Feed.ControlPlayback:output_type -> feed.PlaybackControlResponse
+	24, // 51: feed.Feed.LockstepKline:output_type -> feed.LockstepResponse
+	29, // 52: feed.Feed.SubscribeIndicator:output_type -> feed.IndicatorResponse
+	33, // 53: feed.Feed.SubscribeTrades:output_type -> feed.TradeResponse
+	33, // 54: feed.Feed.ReadHistoricalTrades:output_type -> feed.TradeResponse
+	37, // 55: feed.Feed.GetOrderBook:output_type -> feed.OrderBookResponse
+	37, // 56: feed.Feed.SubscribeOrderBook:output_type -> feed.OrderBookResponse
+	40, // 57: feed.Feed.SubscribeBookTicker:output_type -> feed.BookTickerResponse
+	25, // 58: feed.Feed.ReadFuturesKline:output_type -> feed.KlineResponse
+	45, // 59: feed.Feed.ReadFundingRate:output_type -> feed.FundingRateResponse
+	48, // 60: feed.Feed.SubscribeMarkPrice:output_type -> feed.MarkPriceResponse
+	41, // [41:61] is the sub-list for method output_type
+	21, // [21:41] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_feed_proto_init() }
@@ -631,8 +3882,176 @@ func file_api_proto_feed_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_feed_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadKlineRequest); i {
+		file_api_proto_feed_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeKlineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadKlineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisconnectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisconnectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenPlaybackRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaybackSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaybackSessionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaybackControlRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -643,8 +4062,8 @@ func file_api_proto_feed_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_feed_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse); i {
+		file_api_proto_feed_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaybackControlResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -655,8 +4074,8 @@ func file_api_proto_feed_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_feed_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConfigResponse); i {
+		file_api_proto_feed_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaybackState); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -667,8 +4086,8 @@ func file_api_proto_feed_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_feed_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscriberResponse); i {
+		file_api_proto_feed_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LockstepRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -679,7 +4098,19 @@ func file_api_proto_feed_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_feed_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_feed_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LockstepResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*KlineResponse); i {
 			case 0:
 				return &v.state
@@ -691,14 +4122,290 @@ func file_api_proto_feed_proto_init() {
 				return nil
 			}
 		}
+		file_api_proto_feed_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndicatorSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeIndicatorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndicatorValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndicatorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Trade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeTradesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadTradesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TradeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceLevel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderBookRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeOrderBookRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderBookResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookTicker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeBookTickerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookTickerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Spread); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadFuturesKlineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FundingRate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadFundingRateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FundingRateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MarkPrice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeMarkPriceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_feed_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MarkPriceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_proto_feed_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      5,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -11,7 +11,6 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,22 +19,62 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Feed_GetConfig_FullMethodName           = "/feed.Feed/GetConfig"
-	Feed_GetStatus_FullMethodName           = "/feed.Feed/GetStatus"
-	Feed_GetSubscriber_FullMethodName       = "/feed.Feed/GetSubscriber"
-	Feed_SubscribeKline_FullMethodName      = "/feed.Feed/SubscribeKline"
-	Feed_ReadHistoricalKline_FullMethodName = "/feed.Feed/ReadHistoricalKline"
+	Feed_GetConfig_FullMethodName            = "/feed.Feed/GetConfig"
+	Feed_GetStatus_FullMethodName            = "/feed.Feed/GetStatus"
+	Feed_GetSubscriber_FullMethodName        = "/feed.Feed/GetSubscriber"
+	Feed_DisconnectSubscriber_FullMethodName = "/feed.Feed/DisconnectSubscriber"
+	Feed_SubscribeKline_FullMethodName       = "/feed.Feed/SubscribeKline"
+	Feed_ReadHistoricalKline_FullMethodName  = "/feed.Feed/ReadHistoricalKline"
+	Feed_OpenPlayback_FullMethodName         = "/feed.Feed/OpenPlayback"
+	Feed_GetPlayback_FullMethodName          = "/feed.Feed/GetPlayback"
+	Feed_CancelPlayback_FullMethodName       = "/feed.Feed/CancelPlayback"
+	Feed_ControlPlayback_FullMethodName      = "/feed.Feed/ControlPlayback"
+	Feed_LockstepKline_FullMethodName        = "/feed.Feed/LockstepKline"
+	Feed_SubscribeIndicator_FullMethodName   = "/feed.Feed/SubscribeIndicator"
+	Feed_SubscribeTrades_FullMethodName      = "/feed.Feed/SubscribeTrades"
+	Feed_ReadHistoricalTrades_FullMethodName = "/feed.Feed/ReadHistoricalTrades"
+	Feed_GetOrderBook_FullMethodName         = "/feed.Feed/GetOrderBook"
+	Feed_SubscribeOrderBook_FullMethodName   = "/feed.Feed/SubscribeOrderBook"
+	Feed_SubscribeBookTicker_FullMethodName  = "/feed.Feed/SubscribeBookTicker"
+	Feed_ReadFuturesKline_FullMethodName     = "/feed.Feed/ReadFuturesKline"
+	Feed_ReadFundingRate_FullMethodName      = "/feed.Feed/ReadFundingRate"
+	Feed_SubscribeMarkPrice_FullMethodName   = "/feed.Feed/SubscribeMarkPrice"
 )
 
 // FeedClient is the client API for Feed service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type FeedClient interface {
-	GetConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConfigResponse, error)
-	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatusResponse, error)
-	GetSubscriber(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SubscriberResponse, error)
-	SubscribeKline(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Feed_SubscribeKlineClient, error)
+	GetConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	GetStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Subscribers of every stream of the symbol: klines, trades, order book, book ticker and mark price
+	GetSubscriber(ctx context.Context, in *SubscriberRequest, opts ...grpc.CallOption) (*SubscriberResponse, error)
+	// Admin only, needs "authorization: Bearer <admin.token>" metadata
+	DisconnectSubscriber(ctx context.Context, in *DisconnectRequest, opts ...grpc.CallOption) (*DisconnectResponse, error)
+	SubscribeKline(ctx context.Context, in *SubscribeKlineRequest, opts ...grpc.CallOption) (Feed_SubscribeKlineClient, error)
 	ReadHistoricalKline(ctx context.Context, in *ReadKlineRequest, opts ...grpc.CallOption) (Feed_ReadHistoricalKlineClient, error)
+	// Open a playback session with its own symbol, range, interval and speed, stream it with SubscribeKline
+	OpenPlayback(ctx context.Context, in *OpenPlaybackRequest, opts ...grpc.CallOption) (*PlaybackState, error)
+	GetPlayback(ctx context.Context, in *PlaybackSessionRequest, opts ...grpc.CallOption) (*PlaybackSessionResponse, error)
+	CancelPlayback(ctx context.Context, in *PlaybackSessionRequest, opts ...grpc.CallOption) (*PlaybackSessionResponse, error)
+	// Pace, pause, resume or seek an open playback stream, unimplemented by the live feed
+	ControlPlayback(ctx context.Context, in *PlaybackControlRequest, opts ...grpc.CallOption) (*PlaybackControlResponse, error)
+	// Playback that sends the next batch only after the client acknowledged the previous one
+	LockstepKline(ctx context.Context, opts ...grpc.CallOption) (Feed_LockstepKlineClient, error)
+	// Closed klines with indicators the server keeps up to date, warmed up from the klines it holds
+	SubscribeIndicator(ctx context.Context, in *SubscribeIndicatorRequest, opts ...grpc.CallOption) (Feed_SubscribeIndicatorClient, error)
+	// Live trades of a symbol, served when the trade service is enabled
+	SubscribeTrades(ctx context.Context, in *SubscribeTradesRequest, opts ...grpc.CallOption) (Feed_SubscribeTradesClient, error)
+	ReadHistoricalTrades(ctx context.Context, in *ReadTradesRequest, opts ...grpc.CallOption) (Feed_ReadHistoricalTradesClient, error)
+	// Top levels of the local order book, served when the order book service is enabled
+	GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBookResponse, error)
+	SubscribeOrderBook(ctx context.Context, in *SubscribeOrderBookRequest, opts ...grpc.CallOption) (Feed_SubscribeOrderBookClient, error)
+	// Best bid and ask as they change, served when the book ticker service is enabled
+	SubscribeBookTicker(ctx context.Context, in *SubscribeBookTickerRequest, opts ...grpc.CallOption) (Feed_SubscribeBookTickerClient, error)
+	// USD-M perpetuals, served for the symbols configured with the usdm market
+	ReadFuturesKline(ctx context.Context, in *ReadFuturesKlineRequest, opts ...grpc.CallOption) (Feed_ReadFuturesKlineClient, error)
+	ReadFundingRate(ctx context.Context, in *ReadFundingRateRequest, opts ...grpc.CallOption) (Feed_ReadFundingRateClient, error)
+	SubscribeMarkPrice(ctx context.Context, in *SubscribeMarkPriceRequest, opts ...grpc.CallOption) (Feed_SubscribeMarkPriceClient, error)
 }
 
 type feedClient struct {
@@ -46,7 +85,7 @@ func NewFeedClient(cc grpc.ClientConnInterface) FeedClient {
 	return &feedClient{cc}
 }
 
-func (c *feedClient) GetConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConfigResponse, error) {
+func (c *feedClient) GetConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
 	out := new(ConfigResponse)
 	err := c.cc.Invoke(ctx, Feed_GetConfig_FullMethodName, in, out, opts...)
 	if err != nil {
@@ -55,7 +94,7 @@ func (c *feedClient) GetConfig(ctx context.Context, in *emptypb.Empty, opts ...g
 	return out, nil
 }
 
-func (c *feedClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatusResponse, error) {
+func (c *feedClient) GetStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
 	out := new(StatusResponse)
 	err := c.cc.Invoke(ctx, Feed_GetStatus_FullMethodName, in, out, opts...)
 	if err != nil {
@@ -64,7 +103,7 @@ func (c *feedClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts ...g
 	return out, nil
 }
 
-func (c *feedClient) GetSubscriber(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SubscriberResponse, error) {
+func (c *feedClient) GetSubscriber(ctx context.Context, in *SubscriberRequest, opts ...grpc.CallOption) (*SubscriberResponse, error) {
 	out := new(SubscriberResponse)
 	err := c.cc.Invoke(ctx, Feed_GetSubscriber_FullMethodName, in, out, opts...)
 	if err != nil {
@@ -73,7 +112,16 @@ func (c *feedClient) GetSubscriber(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
-func (c *feedClient) SubscribeKline(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Feed_SubscribeKlineClient, error) {
+func (c *feedClient) DisconnectSubscriber(ctx context.Context, in *DisconnectRequest, opts ...grpc.CallOption) (*DisconnectResponse, error) {
+	out := new(DisconnectResponse)
+	err := c.cc.Invoke(ctx, Feed_DisconnectSubscriber_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedClient) SubscribeKline(ctx context.Context, in *SubscribeKlineRequest, opts ...grpc.CallOption) (Feed_SubscribeKlineClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Feed_ServiceDesc.Streams[0], Feed_SubscribeKline_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
@@ -137,15 +185,372 @@ func (x *feedReadHistoricalKlineClient) Recv() (*KlineResponse, error) {
 	return m, nil
 }
 
+func (c *feedClient) OpenPlayback(ctx context.Context, in *OpenPlaybackRequest, opts ...grpc.CallOption) (*PlaybackState, error) {
+	out := new(PlaybackState)
+	err := c.cc.Invoke(ctx, Feed_OpenPlayback_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedClient) GetPlayback(ctx context.Context, in *PlaybackSessionRequest, opts ...grpc.CallOption) (*PlaybackSessionResponse, error) {
+	out := new(PlaybackSessionResponse)
+	err := c.cc.Invoke(ctx, Feed_GetPlayback_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedClient) CancelPlayback(ctx context.Context, in *PlaybackSessionRequest, opts ...grpc.CallOption) (*PlaybackSessionResponse, error) {
+	out := new(PlaybackSessionResponse)
+	err := c.cc.Invoke(ctx, Feed_CancelPlayback_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedClient) ControlPlayback(ctx context.Context, in *PlaybackControlRequest, opts ...grpc.CallOption) (*PlaybackControlResponse, error) {
+	out := new(PlaybackControlResponse)
+	err := c.cc.Invoke(ctx, Feed_ControlPlayback_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedClient) LockstepKline(ctx context.Context, opts ...grpc.CallOption) (Feed_LockstepKlineClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Feed_ServiceDesc.Streams[2], Feed_LockstepKline_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &feedLockstepKlineClient{stream}
+	return x, nil
+}
+
+type Feed_LockstepKlineClient interface {
+	Send(*LockstepRequest) error
+	Recv() (*LockstepResponse, error)
+	grpc.ClientStream
+}
+
+type feedLockstepKlineClient struct {
+	grpc.ClientStream
+}
+
+func (x *feedLockstepKlineClient) Send(m *LockstepRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *feedLockstepKlineClient) Recv() (*LockstepResponse, error) {
+	m := new(LockstepResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *feedClient) SubscribeIndicator(ctx context.Context, in *SubscribeIndicatorRequest, opts ...grpc.CallOption) (Feed_SubscribeIndicatorClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Feed_ServiceDesc.Streams[3], Feed_SubscribeIndicator_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &feedSubscribeIndicatorClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Feed_SubscribeIndicatorClient interface {
+	Recv() (*IndicatorResponse, error)
+	grpc.ClientStream
+}
+
+type feedSubscribeIndicatorClient struct {
+	grpc.ClientStream
+}
+
+func (x *feedSubscribeIndicatorClient) Recv() (*IndicatorResponse, error) {
+	m := new(IndicatorResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *feedClient) SubscribeTrades(ctx context.Context, in *SubscribeTradesRequest, opts ...grpc.CallOption) (Feed_SubscribeTradesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Feed_ServiceDesc.Streams[4], Feed_SubscribeTrades_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &feedSubscribeTradesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Feed_SubscribeTradesClient interface {
+	Recv() (*TradeResponse, error)
+	grpc.ClientStream
+}
+
+type feedSubscribeTradesClient struct {
+	grpc.ClientStream
+}
+
+func (x *feedSubscribeTradesClient) Recv() (*TradeResponse, error) {
+	m := new(TradeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *feedClient) ReadHistoricalTrades(ctx context.Context, in *ReadTradesRequest, opts ...grpc.CallOption) (Feed_ReadHistoricalTradesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Feed_ServiceDesc.Streams[5], Feed_ReadHistoricalTrades_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &feedReadHistoricalTradesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Feed_ReadHistoricalTradesClient interface {
+	Recv() (*TradeResponse, error)
+	grpc.ClientStream
+}
+
+type feedReadHistoricalTradesClient struct {
+	grpc.ClientStream
+}
+
+func (x *feedReadHistoricalTradesClient) Recv() (*TradeResponse, error) {
+	m := new(TradeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *feedClient) GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBookResponse, error) {
+	out := new(OrderBookResponse)
+	err := c.cc.Invoke(ctx, Feed_GetOrderBook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedClient) SubscribeOrderBook(ctx context.Context, in *SubscribeOrderBookRequest, opts ...grpc.CallOption) (Feed_SubscribeOrderBookClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Feed_ServiceDesc.Streams[6], Feed_SubscribeOrderBook_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &feedSubscribeOrderBookClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Feed_SubscribeOrderBookClient interface {
+	Recv() (*OrderBookResponse, error)
+	grpc.ClientStream
+}
+
+type feedSubscribeOrderBookClient struct {
+	grpc.ClientStream
+}
+
+func (x *feedSubscribeOrderBookClient) Recv() (*OrderBookResponse, error) {
+	m := new(OrderBookResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *feedClient) SubscribeBookTicker(ctx context.Context, in *SubscribeBookTickerRequest, opts ...grpc.CallOption) (Feed_SubscribeBookTickerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Feed_ServiceDesc.Streams[7], Feed_SubscribeBookTicker_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &feedSubscribeBookTickerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Feed_SubscribeBookTickerClient interface {
+	Recv() (*BookTickerResponse, error)
+	grpc.ClientStream
+}
+
+type feedSubscribeBookTickerClient struct {
+	grpc.ClientStream
+}
+
+func (x *feedSubscribeBookTickerClient) Recv() (*BookTickerResponse, error) {
+	m := new(BookTickerResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *feedClient) ReadFuturesKline(ctx context.Context, in *ReadFuturesKlineRequest, opts ...grpc.CallOption) (Feed_ReadFuturesKlineClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Feed_ServiceDesc.Streams[8], Feed_ReadFuturesKline_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &feedReadFuturesKlineClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Feed_ReadFuturesKlineClient interface {
+	Recv() (*KlineResponse, error)
+	grpc.ClientStream
+}
+
+type feedReadFuturesKlineClient struct {
+	grpc.ClientStream
+}
+
+func (x *feedReadFuturesKlineClient) Recv() (*KlineResponse, error) {
+	m := new(KlineResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *feedClient) ReadFundingRate(ctx context.Context, in *ReadFundingRateRequest, opts ...grpc.CallOption) (Feed_ReadFundingRateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Feed_ServiceDesc.Streams[9], Feed_ReadFundingRate_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &feedReadFundingRateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Feed_ReadFundingRateClient interface {
+	Recv() (*FundingRateResponse, error)
+	grpc.ClientStream
+}
+
+type feedReadFundingRateClient struct {
+	grpc.ClientStream
+}
+
+func (x *feedReadFundingRateClient) Recv() (*FundingRateResponse, error) {
+	m := new(FundingRateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *feedClient) SubscribeMarkPrice(ctx context.Context, in *SubscribeMarkPriceRequest, opts ...grpc.CallOption) (Feed_SubscribeMarkPriceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Feed_ServiceDesc.Streams[10], Feed_SubscribeMarkPrice_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &feedSubscribeMarkPriceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Feed_SubscribeMarkPriceClient interface {
+	Recv() (*MarkPriceResponse, error)
+	grpc.ClientStream
+}
+
+type feedSubscribeMarkPriceClient struct {
+	grpc.ClientStream
+}
+
+func (x *feedSubscribeMarkPriceClient) Recv() (*MarkPriceResponse, error) {
+	m := new(MarkPriceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // FeedServer is the server API for Feed service.
 // All implementations must embed UnimplementedFeedServer
 // for forward compatibility
 type FeedServer interface {
-	GetConfig(context.Context, *emptypb.Empty) (*ConfigResponse, error)
-	GetStatus(context.Context, *emptypb.Empty) (*StatusResponse, error)
-	GetSubscriber(context.Context, *emptypb.Empty) (*SubscriberResponse, error)
-	SubscribeKline(*emptypb.Empty, Feed_SubscribeKlineServer) error
+	GetConfig(context.Context, *ConfigRequest) (*ConfigResponse, error)
+	GetStatus(context.Context, *StatusRequest) (*StatusResponse, error)
+	// Subscribers of every stream of the symbol: klines, trades, order book, book ticker and mark price
+	GetSubscriber(context.Context, *SubscriberRequest) (*SubscriberResponse, error)
+	// Admin only, needs "authorization: Bearer <admin.token>" metadata
+	DisconnectSubscriber(context.Context, *DisconnectRequest) (*DisconnectResponse, error)
+	SubscribeKline(*SubscribeKlineRequest, Feed_SubscribeKlineServer) error
 	ReadHistoricalKline(*ReadKlineRequest, Feed_ReadHistoricalKlineServer) error
+	// Open a playback session with its own symbol, range, interval and speed, stream it with SubscribeKline
+	OpenPlayback(context.Context, *OpenPlaybackRequest) (*PlaybackState, error)
+	GetPlayback(context.Context, *PlaybackSessionRequest) (*PlaybackSessionResponse, error)
+	CancelPlayback(context.Context, *PlaybackSessionRequest) (*PlaybackSessionResponse, error)
+	// Pace, pause, resume or seek an open playback stream, unimplemented by the live feed
+	ControlPlayback(context.Context, *PlaybackControlRequest) (*PlaybackControlResponse, error)
+	// Playback that sends the next batch only after the client acknowledged the previous one
+	LockstepKline(Feed_LockstepKlineServer) error
+	// Closed klines with indicators the server keeps up to date, warmed up from the klines it holds
+	SubscribeIndicator(*SubscribeIndicatorRequest, Feed_SubscribeIndicatorServer) error
+	// Live trades of a symbol, served when the trade service is enabled
+	SubscribeTrades(*SubscribeTradesRequest, Feed_SubscribeTradesServer) error
+	ReadHistoricalTrades(*ReadTradesRequest, Feed_ReadHistoricalTradesServer) error
+	// Top levels of the local order book, served when the order book service is enabled
+	GetOrderBook(context.Context, *OrderBookRequest) (*OrderBookResponse, error)
+	SubscribeOrderBook(*SubscribeOrderBookRequest, Feed_SubscribeOrderBookServer) error
+	// Best bid and ask as they change, served when the book ticker service is enabled
+	SubscribeBookTicker(*SubscribeBookTickerRequest, Feed_SubscribeBookTickerServer) error
+	// USD-M perpetuals, served for the symbols configured with the usdm market
+	ReadFuturesKline(*ReadFuturesKlineRequest, Feed_ReadFuturesKlineServer) error
+	ReadFundingRate(*ReadFundingRateRequest, Feed_ReadFundingRateServer) error
+	SubscribeMarkPrice(*SubscribeMarkPriceRequest, Feed_SubscribeMarkPriceServer) error
 	mustEmbedUnimplementedFeedServer()
 }
 
@@ -153,21 +558,66 @@ type FeedServer interface {
 type UnimplementedFeedServer struct {
 }
 
-func (UnimplementedFeedServer) GetConfig(context.Context, *emptypb.Empty) (*ConfigResponse, error) {
+func (UnimplementedFeedServer) GetConfig(context.Context, *ConfigRequest) (*ConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
 }
-func (UnimplementedFeedServer) GetStatus(context.Context, *emptypb.Empty) (*StatusResponse, error) {
+func (UnimplementedFeedServer) GetStatus(context.Context, *StatusRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
-func (UnimplementedFeedServer) GetSubscriber(context.Context, *emptypb.Empty) (*SubscriberResponse, error) {
+func (UnimplementedFeedServer) GetSubscriber(context.Context, *SubscriberRequest) (*SubscriberResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSubscriber not implemented")
 }
-func (UnimplementedFeedServer) SubscribeKline(*emptypb.Empty, Feed_SubscribeKlineServer) error {
+func (UnimplementedFeedServer) DisconnectSubscriber(context.Context, *DisconnectRequest) (*DisconnectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectSubscriber not implemented")
+}
+func (UnimplementedFeedServer) SubscribeKline(*SubscribeKlineRequest, Feed_SubscribeKlineServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeKline not implemented")
 }
 func (UnimplementedFeedServer) ReadHistoricalKline(*ReadKlineRequest, Feed_ReadHistoricalKlineServer) error {
 	return status.Errorf(codes.Unimplemented, "method ReadHistoricalKline not implemented")
 }
+func (UnimplementedFeedServer) OpenPlayback(context.Context, *OpenPlaybackRequest) (*PlaybackState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenPlayback not implemented")
+}
+func (UnimplementedFeedServer) GetPlayback(context.Context, *PlaybackSessionRequest) (*PlaybackSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayback not implemented")
+}
+func (UnimplementedFeedServer) CancelPlayback(context.Context, *PlaybackSessionRequest) (*PlaybackSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPlayback not implemented")
+}
+func (UnimplementedFeedServer) ControlPlayback(context.Context, *PlaybackControlRequest) (*PlaybackControlResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ControlPlayback not implemented")
+}
+func (UnimplementedFeedServer) LockstepKline(Feed_LockstepKlineServer) error {
+	return status.Errorf(codes.Unimplemented, "method LockstepKline not implemented")
+}
+func (UnimplementedFeedServer) SubscribeIndicator(*SubscribeIndicatorRequest, Feed_SubscribeIndicatorServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeIndicator not implemented")
+}
+func (UnimplementedFeedServer) SubscribeTrades(*SubscribeTradesRequest, Feed_SubscribeTradesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTrades not implemented")
+}
+func (UnimplementedFeedServer) ReadHistoricalTrades(*ReadTradesRequest, Feed_ReadHistoricalTradesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadHistoricalTrades not implemented")
+}
+func (UnimplementedFeedServer) GetOrderBook(context.Context, *OrderBookRequest) (*OrderBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderBook not implemented")
+}
+func (UnimplementedFeedServer) SubscribeOrderBook(*SubscribeOrderBookRequest, Feed_SubscribeOrderBookServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeOrderBook not implemented")
+}
+func (UnimplementedFeedServer) SubscribeBookTicker(*SubscribeBookTickerRequest, Feed_SubscribeBookTickerServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBookTicker not implemented")
+}
+func (UnimplementedFeedServer) ReadFuturesKline(*ReadFuturesKlineRequest, Feed_ReadFuturesKlineServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadFuturesKline not implemented")
+}
+func (UnimplementedFeedServer) ReadFundingRate(*ReadFundingRateRequest, Feed_ReadFundingRateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadFundingRate not implemented")
+}
+func (UnimplementedFeedServer) SubscribeMarkPrice(*SubscribeMarkPriceRequest, Feed_SubscribeMarkPriceServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMarkPrice not implemented")
+}
 func (UnimplementedFeedServer) mustEmbedUnimplementedFeedServer() {}
 
 // UnsafeFeedServer may be embedded to opt out of forward compatibility for this service.
@@ -182,7 +632,7 @@ func RegisterFeedServer(s grpc.ServiceRegistrar, srv FeedServer) {
 }
 
 func _Feed_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(ConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -194,13 +644,13 @@ func _Feed_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(inte
 		FullMethod: Feed_GetConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FeedServer).GetConfig(ctx, req.(*emptypb.Empty))
+		return srv.(FeedServer).GetConfig(ctx, req.(*ConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _Feed_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(StatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -212,13 +662,13 @@ func _Feed_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(inte
 		FullMethod: Feed_GetStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FeedServer).GetStatus(ctx, req.(*emptypb.Empty))
+		return srv.(FeedServer).GetStatus(ctx, req.(*StatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _Feed_GetSubscriber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(SubscriberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -230,13 +680,31 @@ func _Feed_GetSubscriber_Handler(srv interface{}, ctx context.Context, dec func(
 		FullMethod: Feed_GetSubscriber_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FeedServer).GetSubscriber(ctx, req.(*emptypb.Empty))
+		return srv.(FeedServer).GetSubscriber(ctx, req.(*SubscriberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feed_DisconnectSubscriber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServer).DisconnectSubscriber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feed_DisconnectSubscriber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServer).DisconnectSubscriber(ctx, req.(*DisconnectRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _Feed_SubscribeKline_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(emptypb.Empty)
+	m := new(SubscribeKlineRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -277,6 +745,290 @@ func (x *feedReadHistoricalKlineServer) Send(m *KlineResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Feed_OpenPlayback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenPlaybackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServer).OpenPlayback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feed_OpenPlayback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServer).OpenPlayback(ctx, req.(*OpenPlaybackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feed_GetPlayback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaybackSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServer).GetPlayback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feed_GetPlayback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServer).GetPlayback(ctx, req.(*PlaybackSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feed_CancelPlayback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaybackSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServer).CancelPlayback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feed_CancelPlayback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServer).CancelPlayback(ctx, req.(*PlaybackSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feed_ControlPlayback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaybackControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServer).ControlPlayback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feed_ControlPlayback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServer).ControlPlayback(ctx, req.(*PlaybackControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feed_LockstepKline_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FeedServer).LockstepKline(&feedLockstepKlineServer{stream})
+}
+
+type Feed_LockstepKlineServer interface {
+	Send(*LockstepResponse) error
+	Recv() (*LockstepRequest, error)
+	grpc.ServerStream
+}
+
+type feedLockstepKlineServer struct {
+	grpc.ServerStream
+}
+
+func (x *feedLockstepKlineServer) Send(m *LockstepResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *feedLockstepKlineServer) Recv() (*LockstepRequest, error) {
+	m := new(LockstepRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Feed_SubscribeIndicator_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeIndicatorRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeedServer).SubscribeIndicator(m, &feedSubscribeIndicatorServer{stream})
+}
+
+type Feed_SubscribeIndicatorServer interface {
+	Send(*IndicatorResponse) error
+	grpc.ServerStream
+}
+
+type feedSubscribeIndicatorServer struct {
+	grpc.ServerStream
+}
+
+func (x *feedSubscribeIndicatorServer) Send(m *IndicatorResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Feed_SubscribeTrades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTradesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeedServer).SubscribeTrades(m, &feedSubscribeTradesServer{stream})
+}
+
+type Feed_SubscribeTradesServer interface {
+	Send(*TradeResponse) error
+	grpc.ServerStream
+}
+
+type feedSubscribeTradesServer struct {
+	grpc.ServerStream
+}
+
+func (x *feedSubscribeTradesServer) Send(m *TradeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Feed_ReadHistoricalTrades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadTradesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeedServer).ReadHistoricalTrades(m, &feedReadHistoricalTradesServer{stream})
+}
+
+type Feed_ReadHistoricalTradesServer interface {
+	Send(*TradeResponse) error
+	grpc.ServerStream
+}
+
+type feedReadHistoricalTradesServer struct {
+	grpc.ServerStream
+}
+
+func (x *feedReadHistoricalTradesServer) Send(m *TradeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Feed_GetOrderBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServer).GetOrderBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feed_GetOrderBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServer).GetOrderBook(ctx, req.(*OrderBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feed_SubscribeOrderBook_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeOrderBookRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeedServer).SubscribeOrderBook(m, &feedSubscribeOrderBookServer{stream})
+}
+
+type Feed_SubscribeOrderBookServer interface {
+	Send(*OrderBookResponse) error
+	grpc.ServerStream
+}
+
+type feedSubscribeOrderBookServer struct {
+	grpc.ServerStream
+}
+
+func (x *feedSubscribeOrderBookServer) Send(m *OrderBookResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Feed_SubscribeBookTicker_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBookTickerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeedServer).SubscribeBookTicker(m, &feedSubscribeBookTickerServer{stream})
+}
+
+type Feed_SubscribeBookTickerServer interface {
+	Send(*BookTickerResponse) error
+	grpc.ServerStream
+}
+
+type feedSubscribeBookTickerServer struct {
+	grpc.ServerStream
+}
+
+func (x *feedSubscribeBookTickerServer) Send(m *BookTickerResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Feed_ReadFuturesKline_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadFuturesKlineRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeedServer).ReadFuturesKline(m, &feedReadFuturesKlineServer{stream})
+}
+
+type Feed_ReadFuturesKlineServer interface {
+	Send(*KlineResponse) error
+	grpc.ServerStream
+}
+
+type feedReadFuturesKlineServer struct {
+	grpc.ServerStream
+}
+
+func (x *feedReadFuturesKlineServer) Send(m *KlineResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Feed_ReadFundingRate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadFundingRateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeedServer).ReadFundingRate(m, &feedReadFundingRateServer{stream})
+}
+
+type Feed_ReadFundingRateServer interface {
+	Send(*FundingRateResponse) error
+	grpc.ServerStream
+}
+
+type feedReadFundingRateServer struct {
+	grpc.ServerStream
+}
+
+func (x *feedReadFundingRateServer) Send(m *FundingRateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Feed_SubscribeMarkPrice_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMarkPriceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeedServer).SubscribeMarkPrice(m, &feedSubscribeMarkPriceServer{stream})
+}
+
+type Feed_SubscribeMarkPriceServer interface {
+	Send(*MarkPriceResponse) error
+	grpc.ServerStream
+}
+
+type feedSubscribeMarkPriceServer struct {
+	grpc.ServerStream
+}
+
+func (x *feedSubscribeMarkPriceServer) Send(m *MarkPriceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Feed_ServiceDesc is the grpc.ServiceDesc for Feed service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -296,6 +1048,30 @@ var Feed_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSubscriber",
 			Handler:    _Feed_GetSubscriber_Handler,
 		},
+		{
+			MethodName: "DisconnectSubscriber",
+			Handler:    _Feed_DisconnectSubscriber_Handler,
+		},
+		{
+			MethodName: "OpenPlayback",
+			Handler:    _Feed_OpenPlayback_Handler,
+		},
+		{
+			MethodName: "GetPlayback",
+			Handler:    _Feed_GetPlayback_Handler,
+		},
+		{
+			MethodName: "CancelPlayback",
+			Handler:    _Feed_CancelPlayback_Handler,
+		},
+		{
+			MethodName: "ControlPlayback",
+			Handler:    _Feed_ControlPlayback_Handler,
+		},
+		{
+			MethodName: "GetOrderBook",
+			Handler:    _Feed_GetOrderBook_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -308,6 +1084,52 @@ var Feed_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Feed_ReadHistoricalKline_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "LockstepKline",
+			Handler:       _Feed_LockstepKline_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeIndicator",
+			Handler:       _Feed_SubscribeIndicator_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeTrades",
+			Handler:       _Feed_SubscribeTrades_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadHistoricalTrades",
+			Handler:       _Feed_ReadHistoricalTrades_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeOrderBook",
+			Handler:       _Feed_SubscribeOrderBook_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeBookTicker",
+			Handler:       _Feed_SubscribeBookTicker_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadFuturesKline",
+			Handler:       _Feed_ReadFuturesKline_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadFundingRate",
+			Handler:       _Feed_ReadFundingRate_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeMarkPrice",
+			Handler:       _Feed_SubscribeMarkPrice_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/proto/feed.proto",
 }
@@ -6,119 +6,449 @@ PlaybackServer is used to backtest trading strategies with same interface as Fee
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
 	"github.com/BullionBear/crypto-feed/domain/pgdb"
+	"github.com/BullionBear/crypto-feed/pkg/service"
 	log "github.com/sirupsen/logrus"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+const subscriberIDKey = "subscriber-id"
+
+// sessionTTL is how long a session can still be queried once no stream is attached to it, whether it was
+// never streamed, dropped, finished or cancelled. Expired sessions are removed when the next one is opened.
+const sessionTTL = 10 * time.Minute
+
+// KlineSource serves the stored klines of a symbol and interval, *pgdb.PgDatabase and
+// *archive.DirSource are implementations.
+type KlineSource interface {
+	QueryKlines(symbol, interval string, startTime, endTime int64) ([]pgdb.PlaybackKline, error)
+	KlineRange(symbol, interval string) (int64, int64, error)
+}
+
 type playbackServer struct {
 	pb.UnimplementedFeedServer
-	db        *pgdb.PgDatabase
+	source    KlineSource
+	symbol    string
 	startTime int64
 	endTime   int64
-	sleepMs   int64
+	speed     float64 // Default speed of new sessions, 0 sends as fast as possible
+
+	sessionMutex sync.Mutex
+	sessions     map[int64]*playbackSession
+	nextID       int64
 }
 
-func NewPlaybackServer(db *pgdb.PgDatabase, startTime, endTime int64) *playbackServer {
+func NewPlaybackServer(source KlineSource, symbol string, startTime, endTime int64, speed float64) *playbackServer {
 	return &playbackServer{
-		db:        db,
+		source:    source,
+		symbol:    strings.ToUpper(symbol),
 		startTime: startTime,
 		endTime:   endTime,
-		sleepMs:   0,
+		speed:     speed,
+		sessions:  make(map[int64]*playbackSession),
 	}
 }
 
-func (s *playbackServer) GetConfig(ctx context.Context, in *emptypb.Empty) (*pb.ConfigResponse, error) {
+func (s *playbackServer) GetConfig(ctx context.Context, in *pb.ConfigRequest) (*pb.ConfigResponse, error) {
+	symbol := s.resolveSymbol(in.Symbol)
+	start, end, err := s.playbackRange(symbol, "1s")
+	if err != nil {
+		return nil, err
+	}
 	return &pb.ConfigResponse{
-		Symbol: "",
-		Length: 0,
+		Symbol: symbol,
+		Length: (end-start)/1000 + 1,
 	}, nil
 }
 
-func (s *playbackServer) GetStatus(ctx context.Context, in *emptypb.Empty) (*pb.StatusResponse, error) {
+func (s *playbackServer) GetStatus(ctx context.Context, in *pb.StatusRequest) (*pb.StatusResponse, error) {
+	start, end, err := s.playbackRange(s.resolveSymbol(in.Symbol), "1s")
+	if err != nil {
+		return nil, err
+	}
 	return &pb.StatusResponse{
 		Status: pb.Status_OK,
-		Start:  s.startTime,
-		End:    s.endTime,
-		Size:   s.endTime - s.startTime + 1,
+		Start:  start,
+		End:    end,
+		Size:   (end-start)/1000 + 1,
 	}, nil
 }
 
-func (s *playbackServer) GetSubscriber(ctx context.Context, in *emptypb.Empty) (*pb.SubscriberResponse, error) {
+func (s *playbackServer) resolveSymbol(symbol string) string {
+	if symbol == "" {
+		return s.symbol
+	}
+	return strings.ToUpper(symbol)
+}
+
+// playbackRange is the configured range narrowed to the klines of interval stored for symbol.
+func (s *playbackServer) playbackRange(symbol, interval string) (int64, int64, error) {
+	first, last, err := s.source.KlineRange(symbol, interval)
+	if err != nil {
+		return 0, 0, status.Errorf(codes.NotFound, "klines of %s are not existed: %v", symbol, err)
+	}
+	if s.startTime > first {
+		first = s.startTime
+	}
+	if s.endTime != 0 && s.endTime < last {
+		last = s.endTime
+	}
+	return first, last, nil
+}
+
+// clampRange narrows the requested [start, end] to [first, last], a zero bound is left open.
+func clampRange(first, last, start, end int64) (int64, int64) {
+	if start < first {
+		start = first
+	}
+	if end == 0 || end > last {
+		end = last
+	}
+	return start, end
+}
+
+func (s *playbackServer) GetSubscriber(ctx context.Context, in *pb.SubscriberRequest) (*pb.SubscriberResponse, error) {
+	subscribers := make([]int64, 0)
+	for _, session := range s.listSessions() {
+		if session.state().Status == pb.PlaybackStatus_PLAYBACK_PLAYING {
+			subscribers = append(subscribers, session.id)
+		}
+	}
 	return &pb.SubscriberResponse{
-		Subscribers: make([]int64, 0),
+		Subscribers: subscribers,
 	}, nil
 }
 
-func (s *playbackServer) SubscribeKline(in *emptypb.Empty, stream pb.Feed_SubscribeKlineServer) error {
+func (s *playbackServer) OpenPlayback(ctx context.Context, in *pb.OpenPlaybackRequest) (*pb.PlaybackState, error) {
+	session, err := s.openSession(in)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Open playback session %d of %v [%d, %d] %s", session.id, session.symbols, session.start, session.end, session.interval)
+	return session.state(), nil
+}
+
+func (s *playbackServer) GetPlayback(ctx context.Context, in *pb.PlaybackSessionRequest) (*pb.PlaybackSessionResponse, error) {
+	sessions, err := s.findSessions(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PlaybackSessionResponse{Sessions: sessionStates(sessions)}, nil
+}
+
+func (s *playbackServer) CancelPlayback(ctx context.Context, in *pb.PlaybackSessionRequest) (*pb.PlaybackSessionResponse, error) {
+	sessions, err := s.findSessions(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		session.stop()
+	}
+	return &pb.PlaybackSessionResponse{Sessions: sessionStates(sessions)}, nil
+}
+
+func (s *playbackServer) ControlPlayback(ctx context.Context, in *pb.PlaybackControlRequest) (*pb.PlaybackControlResponse, error) {
+	sessions, err := s.findSessions(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	switch in.Action {
+	case pb.PlaybackAction_PLAYBACK_QUERY:
+	case pb.PlaybackAction_PLAYBACK_PAUSE:
+		for _, session := range sessions {
+			session.pause()
+		}
+	case pb.PlaybackAction_PLAYBACK_RESUME:
+		for _, session := range sessions {
+			session.resume()
+		}
+	case pb.PlaybackAction_PLAYBACK_SEEK:
+		for _, session := range sessions {
+			if in.SeekTime < session.start || in.SeekTime > session.end {
+				return nil, status.Errorf(codes.InvalidArgument, "seek time %d is out of [%d, %d] of session %d", in.SeekTime, session.start, session.end, session.id)
+			}
+		}
+		for _, session := range sessions {
+			session.seek(in.SeekTime - in.SeekTime%session.width)
+		}
+	case pb.PlaybackAction_PLAYBACK_SPEED:
+		if in.Speed < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "speed %f is negative", in.Speed)
+		}
+		for _, session := range sessions {
+			session.setSpeed(in.Speed)
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown playback action %d", in.Action)
+	}
+	return &pb.PlaybackControlResponse{States: sessionStates(sessions)}, nil
+}
+
+// openSession validates the request, filling the blanks from the server config, and registers a session.
+func (s *playbackServer) openSession(in *pb.OpenPlaybackRequest) (*playbackSession, error) {
+	symbols := []string{s.resolveSymbol(in.Symbol)}
+	if len(in.Symbols) > 0 {
+		symbols = make([]string, 0, len(in.Symbols))
+		isAdded := make(map[string]bool)
+		for _, symbol := range in.Symbols {
+			symbol = s.resolveSymbol(symbol)
+			if isAdded[symbol] {
+				return nil, status.Errorf(codes.InvalidArgument, "symbol %s is repeated", symbol)
+			}
+			isAdded[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+	interval := in.Interval
+	if interval == "" {
+		interval = "1s"
+	}
+	width, err := service.IntervalMs(interval)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "interval %s: %v", interval, err)
+	}
+	// Read the tables of the interval when every symbol has one, otherwise roll the 1s klines up
+	source := interval
+	for _, symbol := range symbols {
+		if _, _, err := s.source.KlineRange(symbol, source); err != nil {
+			source = "1s"
+			break
+		}
+	}
+	// The session spans the klines stored for any of its symbols
+	first, last := int64(math.MaxInt64), int64(math.MinInt64)
+	for _, symbol := range symbols {
+		symbolFirst, symbolLast, err := s.playbackRange(symbol, source)
+		if err != nil {
+			return nil, err
+		}
+		first = min(first, symbolFirst)
+		last = max(last, symbolLast)
+	}
+	start, end := clampRange(first, last, in.Start, in.End)
+	start = start - start%width
+	if start > end {
+		return nil, status.Errorf(codes.InvalidArgument, "range [%d, %d] is out of [%d, %d]", in.Start, in.End, first, last)
+	}
+	if in.Speed < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "speed %f is negative", in.Speed)
+	}
+	speed := s.speed
+	if in.Speed > 0 {
+		speed = in.Speed
+	}
+
+	s.sessionMutex.Lock()
+	defer s.sessionMutex.Unlock()
+	for id, session := range s.sessions {
+		if session.isExpired(sessionTTL) {
+			delete(s.sessions, id)
+		}
+	}
+	s.nextID++
+	session := newPlaybackSession(s.nextID, symbols, start, end, interval, width, speed)
+	session.source = source
+	s.sessions[session.id] = session
+	return session, nil
+}
+
+func (s *playbackServer) removeSession(id int64) {
+	s.sessionMutex.Lock()
+	defer s.sessionMutex.Unlock()
+	delete(s.sessions, id)
+}
+
+// findSessions returns the session with id, or every session when id is 0.
+func (s *playbackServer) findSessions(id int64) ([]*playbackSession, error) {
+	if id == 0 {
+		return s.listSessions(), nil
+	}
+	s.sessionMutex.Lock()
+	defer s.sessionMutex.Unlock()
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "playback session %d is not existed", id)
+	}
+	return []*playbackSession{session}, nil
+}
+
+func (s *playbackServer) listSessions() []*playbackSession {
+	s.sessionMutex.Lock()
+	defer s.sessionMutex.Unlock()
+	sessions := make([]*playbackSession, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].id < sessions[j].id })
+	return sessions
+}
+
+func sessionStates(sessions []*playbackSession) []*pb.PlaybackState {
+	states := make([]*pb.PlaybackState, 0, len(sessions))
+	for _, session := range sessions {
+		states = append(states, session.state())
+	}
+	return states
+}
+
+func (s *playbackServer) SubscribeKline(in *pb.SubscribeKlineRequest, stream pb.Feed_SubscribeKlineServer) error {
 	log.Info("SubscribeKline get called")
 	defer log.Info("Leave SubscribeKline")
-	interval := int64(3_600_000) // 1 hour interval (3600 seconds)
-	currentTime := s.startTime
-	for {
-		endTime := currentTime + interval - 1
-		if endTime >= s.endTime {
-			endTime = s.endTime
+	if in.BarType != pb.BarType_TIME_BAR {
+		return status.Errorf(codes.Unimplemented, "playback only serves time bars")
+	}
+	var session *playbackSession
+	var err error
+	if in.SessionId != 0 {
+		sessions, err := s.findSessions(in.SessionId)
+		if err != nil {
+			return err
 		}
-		//	Query klines from the database
-		log.Infof("Subscribe Kline: Querying klines from %d to %d", currentTime, endTime)
-		klines, err := s.db.QueryKlines(currentTime, endTime)
+		session = sessions[0]
+	} else {
+		// A stream without session gets one that lives as long as the stream
+		session, err = s.openSession(&pb.OpenPlaybackRequest{
+			Symbol:   in.Symbol,
+			Symbols:  in.Symbols,
+			Interval: in.Interval,
+			Speed:    in.Speed,
+		})
 		if err != nil {
 			return err
 		}
+		defer s.removeSession(session.id)
+	}
+	ctx, err := session.attach(stream.Context())
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "playback session %d: %v", session.id, err)
+	}
+	isFinished := false
+	defer func() { session.detach(isFinished) }()
+	// Tell the client which id to pass to ControlPlayback
+	if err := stream.SendHeader(metadata.Pairs(subscriberIDKey, fmt.Sprintf("%d", session.id))); err != nil {
+		return err
+	}
 
-		if len(klines) == 0 {
-			break
+	err = s.play(ctx, session, func(symbol string, kline *service.Kline) error {
+		pbKline := convertToPbKline(kline)
+		pbKline.Symbol = symbol
+		return stream.Send(&pb.KlineResponse{
+			Kline: pbKline,
+		})
+	})
+	if err != nil {
+		if ctx.Err() != nil && stream.Context().Err() == nil {
+			return status.Errorf(codes.Canceled, "playback session %d is cancelled", session.id)
 		}
+		return err
+	}
+	isFinished = true
+	return nil
+}
 
-		for _, kline := range klines {
-			// sleep
-			if s.sleepMs > 0 {
-				time.Sleep(time.Duration(s.sleepMs) * time.Millisecond)
+// play paces the klines of the session through send until its end, following seeks on the way.
+// The klines of several symbols are merged by open time.
+func (s *playbackServer) play(ctx context.Context, session *playbackSession, send func(symbol string, kline *service.Kline) error) error {
+	sourceWidth, _ := service.IntervalMs(session.source)
+	interval := 3600 * sourceWidth // 3600 klines per query
+	currentTime := session.resumeTime()
+	aggs := make([]*service.KlineAggregator, len(session.symbols))
+	for i := range aggs {
+		aggs[i], _ = service.NewKlineAggregator(session.interval)
+	}
+	for currentTime <= session.end {
+		endTime := currentTime + interval - 1
+		if endTime >= session.end {
+			endTime = session.end
+		}
+		//	Query klines from the database
+		log.Infof("Subscribe Kline: Querying klines from %d to %d", currentTime, endTime)
+		bars := make([][]*service.Kline, len(session.symbols))
+		for i, symbol := range session.symbols {
+			klines, err := s.source.QueryKlines(symbol, session.source, currentTime, endTime)
+			if err != nil {
+				return err
 			}
-			if err := stream.Send(&pb.KlineResponse{
-				Kline: playbackToPbKline(&kline),
-			}); err != nil {
+			for j := range klines {
+				bars[i] = append(bars[i], aggs[i].Add(playbackToKline(&klines[j]))...)
+			}
+			if endTime == session.end {
+				// The session ends mid-bucket, the bar so far is sent without IsFinal
+				if bar := aggs[i].Flush(); bar != nil {
+					bars[i] = append(bars[i], bar)
+				}
+			}
+		}
+
+		isSeeking := false
+		for _, bar := range mergeBySymbol(session.symbols, bars) {
+			seekTo, ok, err := session.pace(ctx, bar.kline.OpenTime)
+			if err != nil {
 				return err
 			}
+			if ok {
+				currentTime, isSeeking = seekTo, true
+				break
+			}
+			if err := send(bar.symbol, bar.kline); err != nil {
+				return err
+			}
+			session.sent(bar.kline.OpenTime)
+		}
+		if isSeeking {
+			log.Infof("Subscribe Kline: session %d seeks to %d", session.id, currentTime)
+			for i := range aggs {
+				aggs[i], _ = service.NewKlineAggregator(session.interval)
+			}
+			continue
 		}
 
 		// Increment the current time for the next batch of records
 		currentTime = endTime + 1
-
-		// If we've reached the end of the time range, break the loop
-		if currentTime >= s.endTime {
-			break
-		}
 	}
-
 	return nil
 }
 
 func (s *playbackServer) ReadHistoricalKline(request *pb.ReadKlineRequest, stream pb.Feed_ReadHistoricalKlineServer) error {
-	start := int64(request.Start) / 1000 * 1000
-	end := int64(request.End) / 1000 * 1000
+	if err := checkPlaybackInterval(request.Interval); err != nil {
+		return err
+	}
+	if request.BarType != pb.BarType_TIME_BAR {
+		return status.Errorf(codes.Unimplemented, "playback only serves time bars")
+	}
+	if request.WithSpread {
+		return status.Errorf(codes.Unimplemented, "playback has no spread series")
+	}
+	symbol := s.resolveSymbol(request.Symbol)
+	first, last, err := s.playbackRange(symbol, "1s")
+	if err != nil {
+		return err
+	}
+	start, end := clampRange(first, last, int64(request.Start)/1000*1000, int64(request.End)/1000*1000)
 
 	interval := int64(3_600_000) // 3,600,000 ms interval (3600 seconds)
 	currentTime := start
-	for {
+	for currentTime <= end {
 		endTime := currentTime + interval - 1
-		if endTime >= s.endTime {
-			endTime = s.endTime
+		if endTime >= end {
+			endTime = end
 		}
 		log.Infof("Read History: Querying klines from %d to %d", currentTime, endTime)
-		klines, err := s.db.QueryKlines(currentTime, endTime)
+		klines, err := s.source.QueryKlines(symbol, "1s", currentTime, endTime)
 		if err != nil {
 			return err
 		}
 
-		if len(klines) == 0 {
-			break
-		}
-
 		for _, kline := range klines {
 			if err := stream.Send(&pb.KlineResponse{
 				Kline: playbackToPbKline(&kline),
@@ -129,15 +459,35 @@ func (s *playbackServer) ReadHistoricalKline(request *pb.ReadKlineRequest, strea
 
 		// Increment the current time for the next batch of records
 		currentTime = endTime + 1
+	}
+	return nil
+}
 
-		// If we've reached the end of the time range, break the loop
-		if currentTime > end {
-			break
-		}
+// checkPlaybackInterval rejects intervals other than the 1s klines stored in the database.
+func checkPlaybackInterval(interval string) error {
+	if interval != "" && interval != "1s" {
+		return status.Errorf(codes.InvalidArgument, "playback only serves 1s klines, got %s", interval)
 	}
 	return nil
 }
 
+func playbackToKline(playbackKline *pgdb.PlaybackKline) *service.Kline {
+	return &service.Kline{
+		OpenTime:                 playbackKline.OpenTime,
+		Open:                     playbackKline.Open,
+		High:                     playbackKline.High,
+		Low:                      playbackKline.Low,
+		Close:                    playbackKline.Close,
+		Volume:                   playbackKline.Volume,
+		CloseTime:                playbackKline.CloseTime,
+		QuoteAssetVolume:         playbackKline.QuoteVolume,
+		TradeNum:                 playbackKline.Count,
+		TakerBuyBaseAssetVolume:  playbackKline.TakerBuyVolume,
+		TakerBuyQuoteAssetVolume: playbackKline.TakerBuyQuoteVolume,
+		IsFinal:                  true,
+	}
+}
+
 func playbackToPbKline(playbackKline *pgdb.PlaybackKline) *pb.Kline {
 	return &pb.Kline{
 		OpenTime:                 playbackKline.OpenTime,
@@ -151,5 +501,6 @@ func playbackToPbKline(playbackKline *pgdb.PlaybackKline) *pb.Kline {
 		TradeNum:                 playbackKline.Count,
 		TakerBuyBaseAssetVolume:  playbackKline.TakerBuyVolume,
 		TakerBuyQuoteAssetVolume: playbackKline.TakerBuyQuoteVolume,
+		IsFinal:                  true,
 	}
 }
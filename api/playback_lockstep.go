@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"io"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LockstepKline replays klines in batches, waiting for the client to acknowledge every batch
+// before sending the next one, so a backtest sees the same sequence whatever its speed.
+func (s *playbackServer) LockstepKline(stream pb.Feed_LockstepKlineServer) error {
+	log.Info("LockstepKline get called")
+	defer log.Info("Leave LockstepKline")
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if err := checkPlaybackInterval(in.Interval); err != nil {
+		return err
+	}
+	first, last, err := s.playbackRange(s.symbol, "1s")
+	if err != nil {
+		return err
+	}
+	start, end := clampRange(first, last, in.Start/1000*1000, in.End)
+	if start > end {
+		return status.Errorf(codes.InvalidArgument, "range [%d, %d] is out of [%d, %d]", in.Start, in.End, first, last)
+	}
+	batchSize := int(in.BatchSize)
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+
+	sequence := int64(0)
+	batch := make([]*pb.Kline, 0, batchSize)
+	// send delivers the batch and blocks until the client acknowledges it, the done batch is not acknowledged
+	send := func(done bool) error {
+		sequence++
+		if err := stream.Send(&pb.LockstepResponse{
+			Klines:   batch,
+			Sequence: sequence,
+			Done:     done,
+		}); err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		batch = make([]*pb.Kline, 0, batchSize)
+		ack, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if ack.Ack != sequence {
+			return status.Errorf(codes.InvalidArgument, "expect ack of %d, got %d", sequence, ack.Ack)
+		}
+		return nil
+	}
+
+	interval := int64(3_600_000) // 1 hour interval (3600 seconds)
+	currentTime := start
+	for currentTime <= end {
+		endTime := currentTime + interval - 1
+		if endTime >= end {
+			endTime = end
+		}
+		klines, err := s.source.QueryKlines(s.symbol, "1s", currentTime, endTime)
+		if err != nil {
+			return err
+		}
+		for i := range klines {
+			batch = append(batch, playbackToPbKline(&klines[i]))
+			if len(batch) < batchSize {
+				continue
+			}
+			if err := send(false); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil // The client stopped early
+				}
+				return err
+			}
+		}
+		currentTime = endTime + 1
+	}
+	return send(true)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"testing"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+	"google.golang.org/grpc"
+)
+
+// fakeLockstepStream sends request first and then acknowledges every batch received.
+type fakeLockstepStream struct {
+	grpc.ServerStream
+	request   *pb.LockstepRequest
+	responses []*pb.LockstepResponse
+}
+
+func (stream *fakeLockstepStream) Send(response *pb.LockstepResponse) error {
+	stream.responses = append(stream.responses, response)
+	return nil
+}
+
+func (stream *fakeLockstepStream) Recv() (*pb.LockstepRequest, error) {
+	if len(stream.responses) == 0 {
+		return stream.request, nil
+	}
+	return &pb.LockstepRequest{Ack: stream.responses[len(stream.responses)-1].Sequence}, nil
+}
+
+func TestLockstepKlineRange(t *testing.T) {
+	s := NewPlaybackServer(&fakeKlineSource{first: 10_000, last: 100_000}, "BTCUSDT", 0, 0, 0)
+	stream := &fakeLockstepStream{request: &pb.LockstepRequest{Start: 20_500, BatchSize: 50}}
+	if err := s.LockstepKline(stream); err != nil {
+		t.Fatalf("Error running lockstep: %v", err)
+	}
+	klines := []*pb.Kline{}
+	for _, response := range stream.responses {
+		klines = append(klines, response.Klines...)
+	}
+	if len(klines) != 81 || klines[0].OpenTime != 20_000 || klines[80].OpenTime != 100_000 {
+		t.Fatalf("Expected klines 20000 to 100000, got %d", len(klines))
+	}
+	if last := stream.responses[len(stream.responses)-1]; !last.Done {
+		t.Errorf("Expected the last batch to be done, got %+v", last)
+	}
+
+	stream = &fakeLockstepStream{request: &pb.LockstepRequest{Start: 200_000}}
+	if err := s.LockstepKline(stream); err == nil {
+		t.Errorf("Expected a start after the stored klines to be rejected")
+	}
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/BullionBear/crypto-feed/pkg/service"
+)
+
+type symbolKline struct {
+	symbol string
+	kline  *service.Kline
+}
+
+// mergeBySymbol interleaves the klines of every symbol by open time,
+// klines with the same open time follow the order of symbols.
+func mergeBySymbol(symbols []string, klines [][]*service.Kline) []symbolKline {
+	merged := make([]symbolKline, 0)
+	for i, symbol := range symbols {
+		for _, kline := range klines[i] {
+			merged = append(merged, symbolKline{symbol: symbol, kline: kline})
+		}
+	}
+	// Stable sort keeps the symbol order for ties since each symbol is already in time order
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].kline.OpenTime < merged[j].kline.OpenTime
+	})
+	return merged
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/BullionBear/crypto-feed/pkg/service"
+)
+
+func TestMergeBySymbol(t *testing.T) {
+	klines := [][]*service.Kline{
+		{{OpenTime: 1000}, {OpenTime: 3000}},
+		{{OpenTime: 1000}, {OpenTime: 2000}, {OpenTime: 3000}},
+	}
+	merged := mergeBySymbol([]string{"ETHUSDT", "BTCUSDT"}, klines)
+	expected := []symbolKline{
+		{"ETHUSDT", klines[0][0]},
+		{"BTCUSDT", klines[1][0]},
+		{"BTCUSDT", klines[1][1]},
+		{"ETHUSDT", klines[0][1]},
+		{"BTCUSDT", klines[1][2]},
+	}
+	if len(merged) != len(expected) {
+		t.Fatalf("Expected %d klines, got %d", len(expected), len(merged))
+	}
+	for i := range expected {
+		if merged[i] != expected[i] {
+			t.Errorf("Expected %s at %d in position %d, got %s at %d",
+				expected[i].symbol, expected[i].kline.OpenTime, i, merged[i].symbol, merged[i].kline.OpenTime)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+)
+
+var errSessionIsStreaming = errors.New("session is streaming")
+var errSessionIsCancelled = errors.New("session is cancelled")
+
+type playbackSession struct {
+	id       int64
+	symbols  []string // Merged by open time, ties follow this order
+	start    int64
+	end      int64
+	interval string
+	width    int64  // Interval in milliseconds
+	source   string // Interval read from the database, rolled up into interval
+
+	mutex     sync.Mutex
+	speed     float64 // Multiplier of the real kline spacing, 0 sends as fast as possible
+	paused    bool
+	seekTo    int64 // Pending seek target, 0 if none
+	status    pb.PlaybackStatus
+	idleSince time.Time          // Since when no stream is attached
+	cancel    context.CancelFunc // Stops the attached stream
+
+	currentTime int64 // Open time of the last kline sent
+	wakeCh      chan struct{}
+}
+
+func newPlaybackSession(id int64, symbols []string, start, end int64, interval string, width int64, speed float64) *playbackSession {
+	return &playbackSession{
+		id:        id,
+		symbols:   symbols,
+		start:     start,
+		end:       end,
+		interval:  interval,
+		width:     width,
+		source:    interval,
+		speed:     speed,
+		status:    pb.PlaybackStatus_PLAYBACK_OPENED,
+		idleSince: time.Now(),
+		wakeCh:    make(chan struct{}, 1),
+	}
+}
+
+// attach binds a stream to the session, the returned context is cancelled by CancelPlayback.
+func (ps *playbackSession) attach(ctx context.Context) (context.Context, error) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	switch ps.status {
+	case pb.PlaybackStatus_PLAYBACK_PLAYING:
+		return nil, errSessionIsStreaming
+	case pb.PlaybackStatus_PLAYBACK_CANCELLED:
+		return nil, errSessionIsCancelled
+	}
+	ctx, ps.cancel = context.WithCancel(ctx)
+	ps.status = pb.PlaybackStatus_PLAYBACK_PLAYING
+	return ctx, nil
+}
+
+// detach releases the stream, a session played to its end is finished,
+// otherwise it can be streamed again from where it stopped.
+func (ps *playbackSession) detach(isFinished bool) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	if ps.cancel != nil {
+		ps.cancel()
+		ps.cancel = nil
+	}
+	if ps.status != pb.PlaybackStatus_PLAYBACK_PLAYING {
+		return
+	}
+	ps.idleSince = time.Now()
+	if isFinished {
+		ps.status = pb.PlaybackStatus_PLAYBACK_FINISHED
+	} else {
+		ps.status = pb.PlaybackStatus_PLAYBACK_OPENED
+	}
+}
+
+func (ps *playbackSession) stop() {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	if ps.cancel != nil {
+		ps.cancel()
+		ps.cancel = nil
+	}
+	ps.status = pb.PlaybackStatus_PLAYBACK_CANCELLED
+	ps.idleSince = time.Now()
+}
+
+func (ps *playbackSession) isExpired(ttl time.Duration) bool {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	return ps.status != pb.PlaybackStatus_PLAYBACK_PLAYING && time.Since(ps.idleSince) > ttl
+}
+
+// resumeTime is where a stream attached to the session starts.
+func (ps *playbackSession) resumeTime() int64 {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	if ps.seekTo != 0 {
+		seekTo := ps.seekTo
+		ps.seekTo = 0
+		ps.currentTime = 0
+		return seekTo
+	}
+	if ps.currentTime != 0 {
+		return ps.currentTime + ps.width
+	}
+	return ps.start
+}
+
+func (ps *playbackSession) wake() {
+	select {
+	case ps.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (ps *playbackSession) pause() {
+	ps.mutex.Lock()
+	ps.paused = true
+	ps.mutex.Unlock()
+	ps.wake()
+}
+
+func (ps *playbackSession) resume() {
+	ps.mutex.Lock()
+	ps.paused = false
+	ps.mutex.Unlock()
+	ps.wake()
+}
+
+func (ps *playbackSession) setSpeed(speed float64) {
+	ps.mutex.Lock()
+	ps.speed = speed
+	ps.mutex.Unlock()
+	ps.wake()
+}
+
+func (ps *playbackSession) seek(openTime int64) {
+	ps.mutex.Lock()
+	ps.seekTo = openTime
+	ps.mutex.Unlock()
+	ps.wake()
+}
+
+// pace waits until the kline at openTime is due. It returns early with the target
+// when a seek is requested, and blocks while the session is paused.
+func (ps *playbackSession) pace(ctx context.Context, openTime int64) (int64, bool, error) {
+	for {
+		ps.mutex.Lock()
+		if ps.seekTo != 0 {
+			seekTo := ps.seekTo
+			ps.seekTo = 0
+			ps.currentTime = 0
+			ps.mutex.Unlock()
+			return seekTo, true, nil
+		}
+		paused, speed, lastTime := ps.paused, ps.speed, ps.currentTime
+		ps.mutex.Unlock()
+
+		var timer *time.Timer
+		var timerC <-chan time.Time // Stays nil while paused
+		if !paused {
+			if speed <= 0 || lastTime == 0 || openTime <= lastTime {
+				return 0, false, nil
+			}
+			timer = time.NewTimer(time.Duration(float64(openTime-lastTime) / speed * float64(time.Millisecond)))
+			timerC = timer.C
+		}
+		select {
+		case <-timerC:
+			return 0, false, nil
+		case <-ps.wakeCh:
+			if timer != nil {
+				timer.Stop()
+			}
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return 0, false, ctx.Err()
+		}
+	}
+}
+
+func (ps *playbackSession) sent(openTime int64) {
+	ps.mutex.Lock()
+	ps.currentTime = openTime
+	ps.mutex.Unlock()
+}
+
+func (ps *playbackSession) state() *pb.PlaybackState {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	return &pb.PlaybackState{
+		Id:          ps.id,
+		Speed:       ps.speed,
+		Paused:      ps.paused,
+		CurrentTime: ps.currentTime,
+		Symbol:      ps.symbols[0],
+		Symbols:     ps.symbols,
+		Start:       ps.start,
+		End:         ps.end,
+		Interval:    ps.interval,
+		Status:      ps.status,
+	}
+}
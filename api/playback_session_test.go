@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+)
+
+func TestPlaybackSessionPace(t *testing.T) {
+	ps := newPlaybackSession(1, []string{"BTCUSDT"}, 0, 10_000, "1s", 1000, 10)
+	ps.sent(1000)
+	begin := time.Now()
+	if _, ok, err := ps.pace(context.Background(), 2000); ok || err != nil {
+		t.Fatalf("Expected the kline to be due, got %v %v", ok, err)
+	}
+	if elapsed := time.Since(begin); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected 1s spacing at 10x to take 100ms, took %s", elapsed)
+	}
+
+	ps.pause()
+	done := make(chan int64)
+	go func() {
+		seekTo, _, _ := ps.pace(context.Background(), 3000)
+		done <- seekTo
+	}()
+	select {
+	case <-done:
+		t.Fatalf("Expected pace to block while paused")
+	case <-time.After(200 * time.Millisecond):
+	}
+	ps.seek(5000)
+	if seekTo := <-done; seekTo != 5000 {
+		t.Errorf("Expected seek to 5000, got %d", seekTo)
+	}
+	if state := ps.state(); !state.Paused || state.CurrentTime != 0 {
+		t.Errorf("Expected a paused stream without position, got %+v", state)
+	}
+
+	ps.resume()
+	ps.setSpeed(0)
+	if _, ok, err := ps.pace(context.Background(), 6000); ok || err != nil {
+		t.Errorf("Expected the kline to be due at full speed, got %v %v", ok, err)
+	}
+}
+
+func TestPlaybackSessionLifecycle(t *testing.T) {
+	ps := newPlaybackSession(1, []string{"BTCUSDT"}, 60_000, 600_000, "1m", 60_000, 0)
+	if _, err := ps.attach(context.Background()); err != nil {
+		t.Fatalf("Error attaching: %v", err)
+	}
+	if _, err := ps.attach(context.Background()); err != errSessionIsStreaming {
+		t.Errorf("Expected errSessionIsStreaming, got %v", err)
+	}
+	ps.sent(120_000)
+	ps.detach(false)
+	if resumeTime := ps.resumeTime(); resumeTime != 180_000 {
+		t.Errorf("Expected to resume after the last bar at 180000, got %d", resumeTime)
+	}
+
+	ctx, err := ps.attach(context.Background())
+	if err != nil {
+		t.Fatalf("Error attaching again: %v", err)
+	}
+	ps.stop()
+	if ctx.Err() == nil {
+		t.Errorf("Expected the stream context to be cancelled")
+	}
+	ps.detach(false)
+	if state := ps.state(); state.Status != pb.PlaybackStatus_PLAYBACK_CANCELLED {
+		t.Errorf("Expected a cancelled session, got %s", state.Status)
+	}
+	if _, err := ps.attach(context.Background()); err != errSessionIsCancelled {
+		t.Errorf("Expected errSessionIsCancelled, got %v", err)
+	}
+}
+
+func TestPlaybackSessionExpiry(t *testing.T) {
+	ps := newPlaybackSession(1, []string{"BTCUSDT"}, 0, 10_000, "1s", 1000, 0)
+	if ps.isExpired(time.Hour) {
+		t.Errorf("Expected a new session to be kept within the ttl")
+	}
+	// A session opened but never streamed expires as well
+	if !ps.isExpired(0) {
+		t.Errorf("Expected an idle session to expire")
+	}
+	ps.attach(context.Background())
+	if ps.isExpired(0) {
+		t.Errorf("Expected a streaming session to be kept")
+	}
+	ps.stop()
+	if ps.isExpired(time.Hour) {
+		t.Errorf("Expected a cancelled session to stay queryable within the ttl")
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+)
+
+// fakeKlineSource stores the 1s klines of BTCUSDT opened in [first, last].
+type fakeKlineSource struct {
+	first, last int64
+}
+
+func (src *fakeKlineSource) QueryKlines(symbol, interval string, startTime, endTime int64) ([]pgdb.PlaybackKline, error) {
+	klines := []pgdb.PlaybackKline{}
+	for openTime := max(startTime, src.first); openTime <= min(endTime, src.last); openTime += 1000 {
+		klines = append(klines, pgdb.PlaybackKline{OpenTime: openTime, CloseTime: openTime + 999})
+	}
+	return klines, nil
+}
+
+func (src *fakeKlineSource) KlineRange(symbol, interval string) (int64, int64, error) {
+	if symbol != "BTCUSDT" || interval != "1s" {
+		return 0, 0, errors.New("table is not existed")
+	}
+	return src.first, src.last, nil
+}
+
+func TestOpenSessionRange(t *testing.T) {
+	source := &fakeKlineSource{first: 10_000, last: 100_000}
+	tests := []struct {
+		startTime, endTime int64 // Server config
+		start, end         int64 // Request
+		expectStart        int64
+		expectEnd          int64
+	}{
+		{0, 0, 0, 0, 10_000, 100_000},           // Unbounded config and request
+		{0, 0, 20_500, 50_000, 20_000, 50_000},  // Start is aligned to the interval
+		{0, 0, 5_000, 200_000, 10_000, 100_000}, // Request beyond the stored klines
+		{30_000, 60_000, 0, 0, 30_000, 60_000},  // Configured range
+		{30_000, 60_000, 0, 80_000, 30_000, 60_000},
+	}
+	for _, test := range tests {
+		s := NewPlaybackServer(source, "BTCUSDT", test.startTime, test.endTime, 0)
+		session, err := s.openSession(&pb.OpenPlaybackRequest{Start: test.start, End: test.end})
+		if err != nil {
+			t.Errorf("Error opening [%d, %d] of [%d, %d]: %v", test.start, test.end, test.startTime, test.endTime, err)
+			continue
+		}
+		if session.start != test.expectStart || session.end != test.expectEnd {
+			t.Errorf("Expected [%d, %d], got [%d, %d]", test.expectStart, test.expectEnd, session.start, session.end)
+		}
+	}
+	s := NewPlaybackServer(source, "BTCUSDT", 0, 0, 0)
+	if _, err := s.openSession(&pb.OpenPlaybackRequest{Start: 200_000}); err == nil {
+		t.Errorf("Expected a start after the stored klines to be rejected")
+	}
+	if _, err := s.openSession(&pb.OpenPlaybackRequest{Symbol: "ETHUSDT"}); err == nil {
+		t.Errorf("Expected a symbol without klines to be rejected")
+	}
+}
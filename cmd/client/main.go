@@ -11,12 +11,13 @@ import (
 	"github.com/BullionBear/crypto-feed/api/gen/feed"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 const (
 	serverAddr = "localhost:50051"
+	symbol     = "BTCUSDT"
 )
 
 func main() {
@@ -46,7 +47,7 @@ func main() {
 func getConfig(c feed.FeedClient) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	r, err := c.GetConfig(ctx, &emptypb.Empty{})
+	r, err := c.GetConfig(ctx, &feed.ConfigRequest{Symbol: symbol})
 	if err != nil {
 		log.Printf("could not get config: %v", status.Convert(err).Message())
 		return
@@ -57,7 +58,7 @@ func getConfig(c feed.FeedClient) {
 func getStatus(c feed.FeedClient) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	r, err := c.GetStatus(ctx, &emptypb.Empty{})
+	r, err := c.GetStatus(ctx, &feed.StatusRequest{Symbol: symbol})
 	if err != nil {
 		log.Printf("could not get status: %v", status.Convert(err).Message())
 		return
@@ -68,16 +69,20 @@ func getStatus(c feed.FeedClient) {
 func getSubscriber(c feed.FeedClient) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	r, err := c.GetSubscriber(ctx, &emptypb.Empty{})
+	r, err := c.GetSubscriber(ctx, &feed.SubscriberRequest{Symbol: symbol})
 	if err != nil {
 		log.Printf("could not get subscriber: %v", status.Convert(err).Message())
 		return
 	}
 	log.Printf("Subscribers: %v", r.Subscribers)
+	for _, info := range r.Infos {
+		log.Printf("Subscriber %d: %v", info.Id, info)
+	}
 }
 
 func subscribeKline(c feed.FeedClient) {
-	stream, err := c.SubscribeKline(context.Background(), &emptypb.Empty{})
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "client-name", "example-client")
+	stream, err := c.SubscribeKline(ctx, &feed.SubscribeKlineRequest{Symbol: symbol})
 	if err != nil {
 		log.Printf("could not subscribe to kline: %v", status.Convert(err).Message())
 		return
@@ -127,7 +132,7 @@ func subscribeKline(c feed.FeedClient) {
 func readHistoricalKline(c feed.FeedClient, start, end int64) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	stream, err := c.ReadHistoricalKline(ctx, &feed.ReadKlineRequest{Start: start, End: end})
+	stream, err := c.ReadHistoricalKline(ctx, &feed.ReadKlineRequest{Start: start, End: end, Symbol: symbol})
 	if err != nil {
 		log.Printf("could not read historical kline: %v", status.Convert(err).Message())
 		return
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"time"
+
+	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
+	"github.com/BullionBear/crypto-feed/domain/config"
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+	"github.com/BullionBear/crypto-feed/pkg/export"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func init() {
+	// Set formatter to TextFormatter for human-readable logs
+	log.SetFormatter(&log.TextFormatter{
+		TimestampFormat:           "2006-01-02 15:04:05", // Customize timestamp format
+		FullTimestamp:             true,                  // Show full timestamp instead of elapsed time
+		ForceColors:               true,                  // Force colors even if stdout is not a tty
+		DisableColors:             false,                 // Set to true to disable colors
+		DisableQuote:              true,                  // Disable quoting of values
+		EnvironmentOverrideColors: true,                  // Override coloring based on environment settings
+	})
+}
+
+// fetchFunc passes the klines of symbol and interval opened in [start, end] to handler in open time order.
+type fetchFunc func(symbol, interval string, start, end int64, handler func(kline *pgdb.PlaybackKline) error) error
+
+// queryChunk is the span of open times read from Postgres at once.
+const queryChunk = int64(24 * time.Hour / time.Millisecond)
+
+func main() {
+	configPath := flag.String("config", "path/to/config.json", "path to config file")
+	startFlag := flag.Int64("start", 0, "start time in ms, 0 takes it from the config")
+	endFlag := flag.Int64("end", 0, "end time in ms, 0 takes it from the config")
+	formatFlag := flag.String("format", "", "csv, parquet or arrow, empty takes it from the config")
+	outFlag := flag.String("out", "", "output directory, empty takes it from the config")
+	flag.Parse()
+
+	// Read and parse the configuration file
+	config, err := config.ReadExportConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to read config: %v", err)
+	}
+	if *startFlag != 0 {
+		config.StartTime = *startFlag
+	}
+	if *endFlag != 0 {
+		config.EndTime = *endFlag
+	}
+	if *formatFlag != "" {
+		config.Format = *formatFlag
+	}
+	if *outFlag != "" {
+		config.OutDir = *outFlag
+	}
+	if config.EndTime == 0 {
+		config.EndTime = time.Now().UnixMilli()
+	}
+	if config.StartTime > config.EndTime {
+		log.Fatalf("Start %d is after end %d", config.StartTime, config.EndTime)
+	}
+	if len(config.Symbols) == 0 {
+		log.Fatalf("No symbol to export")
+	}
+	if len(config.Intervals) == 0 {
+		config.Intervals = []string{"1s"}
+	}
+	format, err := export.ParseFormat(config.Format)
+	if err != nil {
+		log.Fatalf("Failed to read format: %v", err)
+	}
+	if err := os.MkdirAll(config.OutDir, 0o755); err != nil {
+		log.Fatalf("Failed to create %s: %v", config.OutDir, err)
+	}
+
+	var fetch fetchFunc
+	switch config.Source {
+	case "feed":
+		conn, err := grpc.NewClient(config.FeedAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Fatalf("Failed to connect to %s: %v", config.FeedAddress, err)
+		}
+		defer conn.Close()
+		fetch = feedFetcher(pb.NewFeedClient(conn))
+	case "postgres":
+		dbConfig := config.Postgres
+		db, err := pgdb.NewPgDatabase(dbConfig.Host, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.DBName, dbConfig.SSLMode, dbConfig.Timezone)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		db.SetTableLayout(pgdb.TableLayout{
+			Template:  dbConfig.TableTemplate,
+			Tables:    dbConfig.Tables,
+			LongTable: dbConfig.LongTable,
+		})
+		fetch = postgresFetcher(db)
+	default:
+		log.Fatalf("Unknown export source %s, use feed or postgres", config.Source)
+	}
+
+	nFailed := 0
+	for _, symbol := range config.Symbols {
+		for _, interval := range config.Intervals {
+			exporter := export.NewExporter(config.OutDir, symbol, interval, format, config.PartitionByDay)
+			nRows := 0
+			err := fetch(symbol, interval, config.StartTime, config.EndTime, func(kline *pgdb.PlaybackKline) error {
+				nRows++
+				return exporter.Add(kline)
+			})
+			if err == nil {
+				err = exporter.Close()
+			} else {
+				exporter.Discard()
+			}
+			if err != nil {
+				log.Errorf("Fail to export %s %s: %v", symbol, interval, err)
+				nFailed++
+				continue
+			}
+			if nRows == 0 {
+				log.Warnf("No kline of %s %s in [%d, %d]", symbol, interval, config.StartTime, config.EndTime)
+				continue
+			}
+			log.Infof("Exported %d klines of %s %s into %v", nRows, symbol, interval, exporter.Files())
+		}
+	}
+	if nFailed > 0 {
+		log.Errorf("%d of %d exports failed", nFailed, len(config.Symbols)*len(config.Intervals))
+		os.Exit(1)
+	}
+}
+
+// feedFetcher reads klines with ReadHistoricalKline, the kline still in progress is left out.
+func feedFetcher(client pb.FeedClient) fetchFunc {
+	return func(symbol, interval string, start, end int64, handler func(kline *pgdb.PlaybackKline) error) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := client.ReadHistoricalKline(ctx, &pb.ReadKlineRequest{
+			Start:    start,
+			End:      end,
+			Symbol:   symbol,
+			Interval: interval,
+		})
+		if err != nil {
+			return err
+		}
+		for {
+			response, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			kline := response.Kline
+			if !kline.IsFinal {
+				continue
+			}
+			if err := handler(&pgdb.PlaybackKline{
+				OpenTime:            kline.OpenTime,
+				Open:                kline.Open,
+				High:                kline.High,
+				Low:                 kline.Low,
+				Close:               kline.Close,
+				Volume:              kline.Volume,
+				CloseTime:           kline.CloseTime,
+				QuoteVolume:         kline.QuoteAssetVolume,
+				Count:               kline.TradeNum,
+				TakerBuyVolume:      kline.TakerBuyBaseAssetVolume,
+				TakerBuyQuoteVolume: kline.TakerBuyQuoteAssetVolume,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// postgresFetcher reads the table of symbol and interval a day at a time.
+func postgresFetcher(db *pgdb.PgDatabase) fetchFunc {
+	return func(symbol, interval string, start, end int64, handler func(kline *pgdb.PlaybackKline) error) error {
+		for currentTime := start; currentTime <= end; currentTime += queryChunk {
+			klines, err := db.QueryKlines(symbol, interval, currentTime, min(currentTime+queryChunk-1, end))
+			if err != nil {
+				return err
+			}
+			for i := range klines {
+				if err := handler(&klines[i]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
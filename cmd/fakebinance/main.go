@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+
+	"github.com/BullionBear/crypto-feed/domain/config"
+	"github.com/BullionBear/crypto-feed/pkg/fakebinance"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	// Set formatter to TextFormatter for human-readable logs
+	log.SetFormatter(&log.TextFormatter{
+		TimestampFormat:           "2006-01-02 15:04:05", // Customize timestamp format
+		FullTimestamp:             true,                  // Show full timestamp instead of elapsed time
+		ForceColors:               true,                  // Force colors even if stdout is not a tty
+		DisableColors:             false,                 // Set to true to disable colors
+		DisableQuote:              true,                  // Disable quoting of values
+		EnvironmentOverrideColors: true,                  // Override coloring based on environment settings
+	})
+}
+
+func main() {
+	configPath := flag.String("config", "path/to/config.json", "path to config file")
+	flag.Parse()
+
+	// Read and parse the configuration file
+	config, err := config.ReadFakeBinanceConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to read config: %v", err)
+	}
+
+	source := fakebinance.NewGeneratedSource(config.Seed)
+	if config.Script != "" {
+		script, err := fakebinance.ReadScript(config.Script)
+		if err != nil {
+			log.Fatalf("Failed to read script: %v", err)
+		}
+		source = fakebinance.NewScriptedSource(script)
+	}
+	server := fakebinance.NewServer(source, fakebinance.Options{
+		StartTime:       config.StartTime,
+		DisconnectEvery: config.DisconnectEvery,
+		DuplicateEvery:  config.DuplicateEvery,
+		GapEvery:        config.GapEvery,
+		RateLimitEvery:  config.RateLimitEvery,
+		PartialUpdates:  config.PartialUpdates,
+	})
+
+	addr := ":" + fmt.Sprintf("%d", config.Port)
+	log.Infof("fake binance listening at %s", addr)
+	if err := http.ListenAndServe(addr, server); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/BullionBear/crypto-feed/domain/config"
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+	"github.com/BullionBear/crypto-feed/pkg/archive"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	// Set formatter to TextFormatter for human-readable logs
+	log.SetFormatter(&log.TextFormatter{
+		TimestampFormat:           "2006-01-02 15:04:05", // Customize timestamp format
+		FullTimestamp:             true,                  // Show full timestamp instead of elapsed time
+		ForceColors:               true,                  // Force colors even if stdout is not a tty
+		DisableColors:             false,                 // Set to true to disable colors
+		DisableQuote:              true,                  // Disable quoting of values
+		EnvironmentOverrideColors: true,                  // Override coloring based on environment settings
+	})
+}
+
+type tableKey struct {
+	symbol   string
+	interval string
+}
+
+type importRange struct {
+	first int64
+	last  int64
+}
+
+func main() {
+	configPath := flag.String("config", "path/to/config.json", "path to config file")
+	symbolFlag := flag.String("symbol", "", "symbol of the files, empty takes it from the file names")
+	intervalFlag := flag.String("interval", "", "interval of the files, empty takes it from the file names")
+	flag.Parse()
+
+	// Read and parse the configuration file
+	config, err := config.ReadImportConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to read config: %v", err)
+	}
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100_000
+	}
+	dbConfig := config.Postgres
+	db, err := pgdb.NewPgDatabase(dbConfig.Host, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.DBName, dbConfig.SSLMode, dbConfig.Timezone)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	db.SetTableLayout(pgdb.TableLayout{
+		Template:  dbConfig.TableTemplate,
+		Tables:    dbConfig.Tables,
+		LongTable: dbConfig.LongTable,
+	})
+
+	paths, err := listArchives(flag.Args())
+	if err != nil {
+		log.Fatalf("Failed to list files: %v", err)
+	}
+	if len(paths) == 0 {
+		log.Fatalf("No zip or csv file to import")
+	}
+
+	ranges := make(map[tableKey]*importRange)
+	nFailed := 0
+	for _, path := range paths {
+		key := tableKey{symbol: *symbolFlag, interval: *intervalFlag}
+		if symbol, interval, ok := archive.ParseName(path); ok {
+			if key.symbol == "" {
+				key.symbol = symbol
+			}
+			if key.interval == "" {
+				key.interval = interval
+			}
+		}
+		if key.symbol == "" || key.interval == "" {
+			log.Errorf("Skip %s: unable to tell its symbol and interval, use -symbol and -interval", path)
+			nFailed++
+			continue
+		}
+		if _, ok := ranges[key]; !ok {
+			if err := db.EnsureKlineTable(key.symbol, key.interval); err != nil {
+				log.Fatalf("Failed to prepare table of %s %s: %v", key.symbol, key.interval, err)
+			}
+			ranges[key] = &importRange{}
+		}
+		if err := importFile(db, path, key, batchSize, ranges[key]); err != nil {
+			log.Errorf("Fail to import %s: %v", path, err)
+			nFailed++
+		}
+	}
+
+	// Gaps are reported over everything stored in the imported span, not only the files
+	for key, span := range ranges {
+		if span.first == 0 {
+			continue
+		}
+		width, ok := archive.IntervalMs(key.interval)
+		if !ok {
+			log.Infof("%s %s: no gap check for an interval without a fixed width", key.symbol, key.interval)
+			continue
+		}
+		gaps, err := db.KlineGaps(key.symbol, key.interval, span.first, span.last, width)
+		if err != nil {
+			log.Errorf("Fail to check gaps of %s %s: %v", key.symbol, key.interval, err)
+			continue
+		}
+		log.Infof("%s %s: %d gaps in [%d, %d]", key.symbol, key.interval, len(gaps), span.first, span.last)
+		for _, gap := range gaps {
+			log.Warnf("%s %s: missing [%d, %d], %d klines", key.symbol, key.interval, gap.Start, gap.End, (gap.End-gap.Start)/width+1)
+		}
+	}
+	if nFailed > 0 {
+		log.Errorf("%d of %d files failed", nFailed, len(paths))
+		os.Exit(1)
+	}
+}
+
+// listArchives expands directories into the zip and csv files inside them, sorted by path.
+func listArchives(args []string) ([]string, error) {
+	paths := []string{}
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		err = filepath.WalkDir(arg, func(path string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && archive.IsArchive(path) {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func importFile(db *pgdb.PgDatabase, path string, key tableKey, batchSize int, span *importRange) error {
+	isVerified, err := archive.VerifyChecksum(path)
+	if err != nil {
+		return err
+	}
+	nRead, nInserted := 0, int64(0)
+	batch := make([]pgdb.PlaybackKline, 0, batchSize)
+	flush := func() error {
+		inserted, err := db.CopyKlines(key.symbol, key.interval, batch)
+		if err != nil {
+			return err
+		}
+		nInserted += inserted
+		batch = batch[:0]
+		return nil
+	}
+	err = archive.ReadFile(path, func(kline *pgdb.PlaybackKline) error {
+		nRead++
+		if span.first == 0 || kline.OpenTime < span.first {
+			span.first = kline.OpenTime
+		}
+		if kline.OpenTime > span.last {
+			span.last = kline.OpenTime
+		}
+		batch = append(batch, *kline)
+		if len(batch) < batchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	log.Infof("Imported %s into %s %s: %d rows read, %d new, %d already stored (checksum verified: %v)",
+		path, key.symbol, key.interval, nRead, nInserted, int64(nRead)-nInserted, isVerified)
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
 	"github.com/BullionBear/crypto-feed/domain/config"
 	"github.com/BullionBear/crypto-feed/domain/pgdb"
+	"github.com/BullionBear/crypto-feed/pkg/archive"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
@@ -41,14 +42,33 @@ func main() {
 	}
 	s := grpc.NewServer()
 	// New Resources
-	dbConfig := config.Postgres
-	log.Infof("Postgres config: %v", dbConfig)
-	db, err := pgdb.NewPgDatabase(dbConfig.Host, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.DBName, dbConfig.SSLMode, dbConfig.Timezone)
-	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	var source api.KlineSource
+	switch config.Source {
+	case "", "postgres":
+		dbConfig := config.Postgres
+		log.Infof("Postgres config: %v", dbConfig)
+		db, err := pgdb.NewPgDatabase(dbConfig.Host, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.DBName, dbConfig.SSLMode, dbConfig.Timezone)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		db.SetTableLayout(pgdb.TableLayout{
+			Template:  dbConfig.TableTemplate,
+			Tables:    dbConfig.Tables,
+			LongTable: dbConfig.LongTable,
+		})
+		source = db
+	case "files":
+		log.Infof("Kline archives in %s", config.DataDir)
+		dirSource, err := archive.NewDirSource(config.DataDir)
+		if err != nil {
+			log.Fatalf("Failed to index %s: %v", config.DataDir, err)
+		}
+		source = dirSource
+	default:
+		log.Fatalf("Unknown playback source %s", config.Source)
 	}
 
-	playbackServer := api.NewPlaybackServer(db, config.StartTime, config.EndTime)
+	playbackServer := api.NewPlaybackServer(source, config.Symbol, config.StartTime, config.EndTime, config.Speed)
 
 	pb.RegisterFeedServer(s, playbackServer)
 	log.Infof("server listening at %s", lis.Addr())
@@ -4,10 +4,13 @@ import (
 	"flag"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/BullionBear/crypto-feed/api"
 	pb "github.com/BullionBear/crypto-feed/api/gen/feed"
 	"github.com/BullionBear/crypto-feed/domain/config"
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+	"github.com/BullionBear/crypto-feed/pkg/recorder"
 	"github.com/BullionBear/crypto-feed/pkg/service"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -45,10 +48,152 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
-	klineSrv := service.NewKLineService(config.Symbol, int64(config.Length))
-	go klineSrv.Run()
-	feedServer := api.NewFeedServer(klineSrv)
+	s := grpc.NewServer(grpc.UnaryInterceptor(api.AdminInterceptor(config.Admin.Token)))
+	overflow, err := service.ParseOverflowPolicy(config.Subscriber.Overflow)
+	if err != nil {
+		log.Fatalf("Invalid subscriber overflow %s: %v", config.Subscriber.Overflow, err)
+	}
+	klineMgr := service.NewKLineManager(
+		service.NewBinanceExchange(config.Exchange.APIURL, config.Exchange.WsURL),
+		service.SubscriberOptions{
+			QueueSize: config.Subscriber.QueueSize,
+			Overflow:  overflow,
+		},
+	)
+	var tradeMgr *service.TradeManager
+	if config.Trades.Enabled {
+		kind, err := service.ParseTradeKind(config.Trades.Kind)
+		if err != nil {
+			log.Fatalf("Invalid trade kind %s: %v", config.Trades.Kind, err)
+		}
+		tradeMgr = service.NewTradeManager(
+			service.NewBinanceTradeExchange(config.Exchange.APIURL, config.Exchange.WsURL),
+			kind,
+			service.SubscriberOptions{
+				QueueSize: config.Subscriber.QueueSize,
+				Overflow:  overflow,
+			},
+		)
+	}
+	tradeLength := int64(config.Trades.Length)
+	if tradeLength <= 0 {
+		tradeLength = 100_000
+	}
+	var bookMgr *service.OrderBookManager
+	if config.OrderBook.Enabled {
+		limit, err := service.ParseBookLimit(config.OrderBook.Limit)
+		if err != nil {
+			log.Fatalf("Invalid order book limit %d: %v", config.OrderBook.Limit, err)
+		}
+		bookMgr = service.NewOrderBookManager(
+			service.NewBinanceBookExchange(config.Exchange.APIURL, config.Exchange.WsURL),
+			limit,
+			service.SubscriberOptions{
+				QueueSize: config.Subscriber.QueueSize,
+				Overflow:  overflow,
+			},
+		)
+	}
+	var tickerMgr *service.BookTickerManager
+	if config.BookTicker.Enabled {
+		tickerMgr = service.NewBookTickerManager(
+			service.NewBinanceTickerExchange(config.Exchange.APIURL, config.Exchange.WsURL),
+			service.SubscriberOptions{
+				QueueSize: config.Subscriber.QueueSize,
+				Overflow:  overflow,
+			},
+		)
+	}
+	futuresMgr := service.NewFuturesManager(
+		service.NewBinanceFuturesExchange(config.Exchange.FuturesAPIURL, config.Exchange.FuturesWsURL),
+		service.SubscriberOptions{
+			QueueSize: config.Subscriber.QueueSize,
+			Overflow:  overflow,
+		},
+	)
+	var db *pgdb.PgDatabase
+	if config.Recorder.Enabled {
+		dbConfig := config.Recorder.Postgres
+		db, err = pgdb.NewPgDatabase(dbConfig.Host, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.DBName, dbConfig.SSLMode, dbConfig.Timezone)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		db.SetTableLayout(pgdb.TableLayout{
+			Template:  dbConfig.TableTemplate,
+			Tables:    dbConfig.Tables,
+			LongTable: dbConfig.LongTable,
+		})
+	}
+	for _, symbolConfig := range config.Symbols {
+		market, err := service.ParseMarketType(symbolConfig.Market)
+		if err != nil {
+			log.Fatalf("Invalid market %s of %s: %v", symbolConfig.Market, symbolConfig.Symbol, err)
+		}
+		if market == service.USDMMarket {
+			// Perpetuals have no 1s klines, they are served by the futures RPCs only
+			if _, err := futuresMgr.Add(symbolConfig.Symbol, int64(symbolConfig.Length)); err != nil {
+				log.Fatalf("Failed to add futures of %s: %v", symbolConfig.Symbol, err)
+			}
+			continue
+		}
+		klineSrv, err := klineMgr.Add(symbolConfig.Symbol, int64(symbolConfig.Length))
+		if err != nil {
+			log.Fatalf("Failed to add symbol %s: %v", symbolConfig.Symbol, err)
+		}
+		if tradeMgr != nil {
+			if _, err := tradeMgr.Add(symbolConfig.Symbol, tradeLength); err != nil {
+				log.Fatalf("Failed to add trades of %s: %v", symbolConfig.Symbol, err)
+			}
+		}
+		if bookMgr != nil {
+			if _, err := bookMgr.Add(symbolConfig.Symbol); err != nil {
+				log.Fatalf("Failed to add order book of %s: %v", symbolConfig.Symbol, err)
+			}
+		}
+		if tickerMgr != nil {
+			length := int64(config.BookTicker.Length)
+			if length <= 0 {
+				length = int64(symbolConfig.Length)
+			}
+			if _, err := tickerMgr.Add(symbolConfig.Symbol, length); err != nil {
+				log.Fatalf("Failed to add book ticker of %s: %v", symbolConfig.Symbol, err)
+			}
+		}
+		if db != nil {
+			if err := db.EnsureKlineTable(symbolConfig.Symbol, "1s"); err != nil {
+				log.Fatalf("Failed to prepare table of %s: %v", symbolConfig.Symbol, err)
+			}
+			rec := recorder.NewRecorder(db, symbolConfig.Symbol, recorder.Options{
+				BatchSize:     config.Recorder.BatchSize,
+				BufferSize:    config.Recorder.BufferSize,
+				FlushInterval: time.Duration(config.Recorder.FlushInterval) * time.Millisecond,
+			})
+			rec.Attach(klineSrv)
+			go rec.Run()
+		}
+		if config.Snapshot.Dir == "" {
+			continue
+		}
+		interval := time.Duration(config.Snapshot.Interval) * time.Second
+		if interval <= 0 {
+			interval = 10 * time.Minute
+		}
+		if err := klineSrv.EnableSnapshot(config.Snapshot.Dir, interval); err != nil {
+			log.Fatalf("Failed to enable snapshot of %s: %v", symbolConfig.Symbol, err)
+		}
+	}
+	klineMgr.Run()
+	if tradeMgr != nil {
+		tradeMgr.Run()
+	}
+	if bookMgr != nil {
+		bookMgr.Run()
+	}
+	if tickerMgr != nil {
+		tickerMgr.Run()
+	}
+	futuresMgr.Run()
+	feedServer := api.NewFeedServer(klineMgr, tradeMgr, bookMgr, tickerMgr, futuresMgr)
 
 	pb.RegisterFeedServer(s, feedServer)
 	log.Infof("server listening at %s", lis.Addr())
@@ -7,9 +7,77 @@ import (
 )
 
 type Config struct {
-	Port   int    `json:"port"` // Port as an integer
+	Port       int              `json:"port"` // Port as an integer
+	Symbol     string           `json:"symbol"`
+	Length     int              `json:"length"`
+	Market     string           `json:"market"` // Market of symbol, see SymbolConfig
+	Symbols    []SymbolConfig   `json:"symbols"`
+	Exchange   ExchangeConfig   `json:"exchange"`
+	Subscriber SubscriberConfig `json:"subscriber"`
+	Snapshot   SnapshotConfig   `json:"snapshot"`
+	Recorder   RecorderConfig   `json:"recorder"`
+	Trades     TradesConfig     `json:"trades"`
+	OrderBook  OrderBookConfig  `json:"order_book"`
+	BookTicker BookTickerConfig `json:"book_ticker"`
+	Admin      AdminConfig      `json:"admin"`
+}
+
+type AdminConfig struct {
+	Token string `json:"token"` // Bearer token of the admin RPCs, empty disables them
+}
+
+// BookTickerConfig streams the best bid and ask of every symbol when Enabled.
+type BookTickerConfig struct {
+	Enabled bool `json:"enabled"`
+	Length  int  `json:"length"` // Seconds of spread kept, 0 matches the kline length of the symbol
+}
+
+// OrderBookConfig maintains a local order book of every symbol when Enabled.
+type OrderBookConfig struct {
+	Enabled bool `json:"enabled"`
+	Limit   int  `json:"limit"` // Levels per side of the REST snapshot: 5, 10, 20, 50, 100, 500, 1000 (default) or 5000
+}
+
+// TradesConfig ingests the trades of every symbol next to its klines when Enabled.
+type TradesConfig struct {
+	Enabled bool   `json:"enabled"`
+	Kind    string `json:"kind"`   // agg_trade (default) or trade
+	Length  int    `json:"length"` // Trades kept in memory per symbol
+}
+
+// RecorderConfig upserts every final kline into Postgres when Enabled.
+type RecorderConfig struct {
+	Enabled       bool           `json:"enabled"`
+	BatchSize     int            `json:"batch_size"`
+	BufferSize    int            `json:"buffer_size"`    // Klines kept in memory while Postgres is unavailable
+	FlushInterval int            `json:"flush_interval"` // Milliseconds between flushes
+	Postgres      PostgresConfig `json:"postgres"`
+}
+
+// SnapshotConfig persists the kline window under Dir, an empty Dir disables it.
+type SnapshotConfig struct {
+	Dir      string `json:"dir"`
+	Interval int    `json:"interval"` // Seconds between snapshots
+}
+
+// SubscriberConfig bounds the queue of every subscriber and decides what happens when it is full.
+type SubscriberConfig struct {
+	QueueSize int    `json:"queue_size"`
+	Overflow  string `json:"overflow"` // drop_oldest, drop_newest or disconnect
+}
+
+// ExchangeConfig overrides the Binance endpoints, e.g. to point at cmd/fakebinance.
+type ExchangeConfig struct {
+	APIURL        string `json:"api_url"`
+	WsURL         string `json:"ws_url"`
+	FuturesAPIURL string `json:"futures_api_url"` // USD-M REST endpoint
+	FuturesWsURL  string `json:"futures_ws_url"`  // USD-M websocket endpoint
+}
+
+type SymbolConfig struct {
 	Symbol string `json:"symbol"`
-	Length int    `json:"length"`
+	Length int    `json:"length"` // 1s klines kept, or 1m klines per price type of a usdm symbol
+	Market string `json:"market"` // spot (default) or usdm
 }
 
 func ReadConfig(path string) (*Config, error) {
@@ -22,6 +90,14 @@ func ReadConfig(path string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Keep single symbol configs working by folding them into the symbol list
+	if config.Symbol != "" {
+		config.Symbols = append(config.Symbols, SymbolConfig{
+			Symbol: config.Symbol,
+			Length: config.Length,
+			Market: config.Market,
+		})
+	}
 	return &config, nil
 }
 
@@ -30,6 +106,9 @@ type PlaybackConfig struct {
 	Symbol    string         `json:"symbol"`
 	StartTime int64          `json:"start_time"`
 	EndTime   int64          `json:"end_time"`
+	Speed     float64        `json:"speed"`    // Multiplier of the real kline spacing, 0 sends as fast as possible
+	Source    string         `json:"source"`   // "postgres" (default) or "files"
+	DataDir   string         `json:"data_dir"` // Directory of kline archives when source is "files"
 	Postgres  PostgresConfig `json:"postgres"`
 }
 
@@ -41,6 +120,10 @@ type PostgresConfig struct {
 	DBName   string `json:"db_name"`
 	SSLMode  string `json:"ssl_mode"`
 	Timezone string `json:"timezone"`
+
+	TableTemplate string            `json:"table_template"` // Table per symbol and interval, default {symbol}_kline_{interval}
+	Tables        map[string]string `json:"tables"`         // Explicit tables keyed by SYMBOL/interval
+	LongTable     string            `json:"long_table"`     // One table for every symbol with a symbol column
 }
 
 func ReadPlaybackConfig(path string) (*PlaybackConfig, error) {
@@ -55,3 +138,72 @@ func ReadPlaybackConfig(path string) (*PlaybackConfig, error) {
 	}
 	return &config, nil
 }
+
+type FakeBinanceConfig struct {
+	Port            int    `json:"port"`
+	Seed            int64  `json:"seed"`
+	Script          string `json:"script"` // Path to scripted klines, empty generates them from seed
+	StartTime       int64  `json:"start_time"`
+	DisconnectEvery int    `json:"disconnect_every"`
+	DuplicateEvery  int    `json:"duplicate_every"`
+	GapEvery        int    `json:"gap_every"`
+	RateLimitEvery  int    `json:"rate_limit_every"`
+	PartialUpdates  bool   `json:"partial_updates"`
+}
+
+func ReadFakeBinanceConfig(path string) (*FakeBinanceConfig, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config FakeBinanceConfig
+	err = json5.Unmarshal(file, &config)
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+type ImportConfig struct {
+	BatchSize int            `json:"batch_size"` // Rows per COPY
+	Postgres  PostgresConfig `json:"postgres"`
+}
+
+func ReadImportConfig(path string) (*ImportConfig, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config ImportConfig
+	err = json5.Unmarshal(file, &config)
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+type ExportConfig struct {
+	Source         string         `json:"source"`       // "feed" reads a running Feed server, "postgres" the database
+	FeedAddress    string         `json:"feed_address"` // host:port of the Feed server
+	Symbols        []string       `json:"symbols"`
+	Intervals      []string       `json:"intervals"` // Empty exports 1s
+	StartTime      int64          `json:"start_time"`
+	EndTime        int64          `json:"end_time"` // 0 is now
+	Format         string         `json:"format"`   // csv, parquet or arrow
+	OutDir         string         `json:"out_dir"`
+	PartitionByDay bool           `json:"partition_by_day"`
+	Postgres       PostgresConfig `json:"postgres"`
+}
+
+func ReadExportConfig(path string) (*ExportConfig, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config ExportConfig
+	err = json5.Unmarshal(file, &config)
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
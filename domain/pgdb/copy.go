@@ -0,0 +1,112 @@
+package pgdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+var klineColumns = []string{
+	"open_time", "open", "high", "low", "close", "volume", "close_time",
+	"quote_volume", "count", "taker_buy_volume", "taker_buy_quote_volume", "ignore",
+}
+
+type Gap struct {
+	Start int64 // Open time of the first missing kline
+	End   int64 // Open time of the last missing kline
+}
+
+// CopyKlines bulk-loads klines with COPY into a temporary table and moves the rows
+// that are not stored yet into the table of symbol and interval, returning how many were new.
+func (pg *PgDatabase) CopyKlines(symbol, interval string, klines []PlaybackKline) (int64, error) {
+	if len(klines) == 0 {
+		return 0, nil
+	}
+	table, isLong := pg.layout.Table(symbol, interval)
+	sqlDB, err := pg.DB.DB()
+	if err != nil {
+		return 0, err
+	}
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	var inserted int64
+	err = conn.Raw(func(driverConn any) error {
+		pgxConn := driverConn.(*stdlib.Conn).Conn()
+		tx, err := pgxConn.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+		staging := "staging_" + strings.ReplaceAll(table, ".", "_")
+		create := fmt.Sprintf("CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP",
+			pgx.Identifier{staging}.Sanitize(), quoteTable(table))
+		if _, err := tx.Exec(ctx, create); err != nil {
+			return err
+		}
+		rows := pgx.CopyFromSlice(len(klines), func(i int) ([]any, error) {
+			k := &klines[i]
+			row := []any{k.OpenTime, k.Open, k.High, k.Low, k.Close, k.Volume, k.CloseTime,
+				k.QuoteVolume, k.Count, k.TakerBuyVolume, k.TakerBuyQuoteVolume, k.Ignore}
+			if isLong {
+				row = append([]any{strings.ToUpper(symbol)}, row...)
+			}
+			return row, nil
+		})
+		columns := klineColumns
+		conflict := "open_time"
+		if isLong {
+			columns = append([]string{"symbol"}, klineColumns...)
+			conflict = "symbol, open_time"
+		}
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{staging}, columns, rows); err != nil {
+			return err
+		}
+		insert := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s ON CONFLICT (%s) DO NOTHING",
+			quoteTable(table), strings.Join(columns, ", "), strings.Join(columns, ", "), pgx.Identifier{staging}.Sanitize(), conflict)
+		tag, err := tx.Exec(ctx, insert)
+		if err != nil {
+			return err
+		}
+		inserted = tag.RowsAffected()
+		return tx.Commit(ctx)
+	})
+	return inserted, err
+}
+
+// KlineGaps lists the missing klines of symbol and interval between startTime and endTime,
+// width is the interval in milliseconds.
+func (pg *PgDatabase) KlineGaps(symbol, interval string, startTime, endTime, width int64) ([]Gap, error) {
+	var rows []struct {
+		OpenTime int64
+		NextTime int64
+	}
+	inner := pg.scope(symbol, interval).
+		Select("open_time, LEAD(open_time) OVER (ORDER BY open_time) AS next_time").
+		Where("open_time BETWEEN ? AND ?", startTime, endTime)
+	result := pg.DB.Table("(?) AS spans", inner).
+		Select("open_time, next_time").
+		Where("next_time - open_time > ?", width).
+		Order("open_time").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	gaps := make([]Gap, 0, len(rows))
+	for _, row := range rows {
+		gaps = append(gaps, Gap{Start: row.OpenTime + width, End: row.NextTime - width})
+	}
+	return gaps, nil
+}
+
+// quoteTable quotes a possibly schema-qualified table the way gorm does.
+func quoteTable(table string) string {
+	return pgx.Identifier(strings.Split(table, ".")).Sanitize()
+}
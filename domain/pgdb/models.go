@@ -15,6 +15,8 @@ type PlaybackKline struct {
 	Ignore              int64
 }
 
-func (PlaybackKline) TableName() string {
-	return "btcusdt_kline_1s"
+// LongKline is a row of a long-format table holding every symbol.
+type LongKline struct {
+	Symbol        string
+	PlaybackKline `gorm:"embedded"`
 }
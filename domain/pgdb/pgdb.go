@@ -2,13 +2,17 @@ package pgdb
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/jackc/pgx/v5"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PgDatabase struct {
-	DB *gorm.DB
+	DB     *gorm.DB
+	layout TableLayout
 }
 
 func NewPgDatabase(host string, port int, user, password, dbName, sslMode, timeZone string) (*PgDatabase, error) {
@@ -21,16 +25,95 @@ func NewPgDatabase(host string, port int, user, password, dbName, sslMode, timeZ
 	return &PgDatabase{DB: db}, nil
 }
 
-func (pg *PgDatabase) QueryKlines(startTime, endTime int64) ([]PlaybackKline, error) {
+// SetTableLayout changes how symbols and intervals map onto tables.
+func (pg *PgDatabase) SetTableLayout(layout TableLayout) {
+	pg.layout = layout
+}
+
+// scope selects the table of symbol and interval, filtering a long-format table by symbol.
+func (pg *PgDatabase) scope(symbol, interval string) *gorm.DB {
+	table, isLong := pg.layout.Table(symbol, interval)
+	db := pg.DB.Table(table)
+	if isLong {
+		db = db.Where("symbol = ?", strings.ToUpper(symbol))
+	}
+	return db
+}
+
+func (pg *PgDatabase) QueryKlines(symbol, interval string, startTime, endTime int64) ([]PlaybackKline, error) {
 	var records []PlaybackKline
-	result := pg.DB.Where("open_time BETWEEN ? AND ?", startTime, endTime).
+	result := pg.scope(symbol, interval).
+		Where("open_time BETWEEN ? AND ?", startTime, endTime).
 		Order("open_time").
 		Find(&records)
 	return records, result.Error
 }
 
-func (pg *PgDatabase) QueryKline(openTime int64) (PlaybackKline, error) {
+func (pg *PgDatabase) QueryKline(symbol, interval string, openTime int64) (PlaybackKline, error) {
 	var record PlaybackKline
-	result := pg.DB.Where("open_time = ?", openTime).First(&record)
+	result := pg.scope(symbol, interval).Where("open_time = ?", openTime).First(&record)
 	return record, result.Error
 }
+
+// KlineRange returns the first and last open time stored for symbol and interval.
+func (pg *PgDatabase) KlineRange(symbol, interval string) (int64, int64, error) {
+	var bounds struct {
+		First *int64
+		Last  *int64
+	}
+	result := pg.scope(symbol, interval).
+		Select("MIN(open_time) AS first, MAX(open_time) AS last").
+		Scan(&bounds)
+	if result.Error != nil {
+		return 0, 0, result.Error
+	}
+	if bounds.First == nil || bounds.Last == nil {
+		return 0, 0, gorm.ErrRecordNotFound
+	}
+	return *bounds.First, *bounds.Last, nil
+}
+
+// EnsureKlineTable creates the table of symbol and interval if needed, with the unique index upserts conflict on.
+func (pg *PgDatabase) EnsureKlineTable(symbol, interval string) error {
+	table, isLong := pg.layout.Table(symbol, interval)
+	if !isLong {
+		if err := pg.DB.Table(table).AutoMigrate(&PlaybackKline{}); err != nil {
+			return err
+		}
+		index := pgx.Identifier{strings.ReplaceAll(table, ".", "_") + "_open_time_key"}.Sanitize()
+		return pg.DB.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s (open_time)", index, quoteTable(table))).Error
+	}
+	if err := pg.DB.Table(table).AutoMigrate(&LongKline{}); err != nil {
+		return err
+	}
+	index := pgx.Identifier{strings.ReplaceAll(table, ".", "_") + "_symbol_open_time_key"}.Sanitize()
+	return pg.DB.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s (symbol, open_time)", index, quoteTable(table))).Error
+}
+
+// UpsertKlines writes klines of symbol and interval, replacing the rows with the same open time.
+func (pg *PgDatabase) UpsertKlines(symbol, interval string, klines []PlaybackKline) error {
+	if len(klines) == 0 {
+		return nil
+	}
+	table, isLong := pg.layout.Table(symbol, interval)
+	if !isLong {
+		result := pg.DB.Table(table).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "open_time"}},
+				UpdateAll: true,
+			}).
+			Create(&klines)
+		return result.Error
+	}
+	rows := make([]LongKline, len(klines))
+	for i := range klines {
+		rows[i] = LongKline{Symbol: strings.ToUpper(symbol), PlaybackKline: klines[i]}
+	}
+	result := pg.DB.Table(table).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "symbol"}, {Name: "open_time"}},
+			UpdateAll: true,
+		}).
+		Create(&rows)
+	return result.Error
+}
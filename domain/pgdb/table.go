@@ -0,0 +1,42 @@
+package pgdb
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultTableTemplate = "{symbol}_kline_{interval}"
+
+type TableLayout struct {
+	Template  string            // Table per symbol and interval, e.g. {symbol}_kline_{interval}
+	Tables    map[string]string // Explicit tables keyed by SYMBOL/interval, e.g. BTCUSDT/1s, override Template
+	LongTable string            // One table for every symbol with a symbol column, may use {interval}
+}
+
+// Table resolves the table of symbol and interval, isLong reports a long-format table
+// whose rows must be filtered by the symbol column.
+func (layout TableLayout) Table(symbol, interval string) (table string, isLong bool) {
+	symbol = strings.ToUpper(symbol)
+	if interval == "" {
+		interval = "1s"
+	}
+	if table, ok := layout.Tables[fmt.Sprintf("%s/%s", symbol, interval)]; ok {
+		return table, false
+	}
+	if layout.LongTable != "" {
+		return expandTable(layout.LongTable, symbol, interval), true
+	}
+	template := layout.Template
+	if template == "" {
+		template = defaultTableTemplate
+	}
+	return expandTable(template, symbol, interval), false
+}
+
+func expandTable(template, symbol, interval string) string {
+	return strings.NewReplacer(
+		"{symbol}", strings.ToLower(symbol),
+		"{SYMBOL}", symbol,
+		"{interval}", interval,
+	).Replace(template)
+}
@@ -0,0 +1,28 @@
+package pgdb
+
+import "testing"
+
+func TestTableLayout(t *testing.T) {
+	layout := TableLayout{
+		Tables: map[string]string{"ETHUSDT/1m": "eth_minutes"},
+	}
+	cases := []struct {
+		layout   TableLayout
+		symbol   string
+		interval string
+		table    string
+		isLong   bool
+	}{
+		{layout, "btcusdt", "", "btcusdt_kline_1s", false},
+		{layout, "BTCUSDT", "1m", "btcusdt_kline_1m", false},
+		{layout, "ethusdt", "1m", "eth_minutes", false},
+		{TableLayout{Template: "kline_{SYMBOL}_{interval}"}, "btcusdt", "1s", "kline_BTCUSDT_1s", false},
+		{TableLayout{LongTable: "kline_{interval}"}, "btcusdt", "1h", "kline_1h", true},
+	}
+	for _, c := range cases {
+		table, isLong := c.layout.Table(c.symbol, c.interval)
+		if table != c.table || isLong != c.isLong {
+			t.Errorf("Expected %s %s in %s (long %v), got %s (long %v)", c.symbol, c.interval, c.table, c.isLong, table, isLong)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package archive
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	pqfile "github.com/apache/arrow/go/v17/parquet/file"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+)
+
+var errChecksumMismatch = errors.New("checksum is mismatched")
+var errColumnNotEnough = errors.New("kline row has not enough columns")
+var errColumnNotExist = errors.New("column is not existed")
+var errColumnNotNumeric = errors.New("column is not numeric")
+
+// archiveName matches Binance public-data files, e.g. BTCUSDT-1s-2023-05.zip or BTCUSDT-1m-2023-05-01.csv
+var archiveName = regexp.MustCompile(`^([A-Z0-9]+)-(\d+[smhdwM])-(\d{4}-\d{2}(?:-\d{2})?)\.(zip|csv|parquet)$`)
+
+func ParseName(path string) (symbol, interval string, ok bool) {
+	match := archiveName.FindStringSubmatch(filepath.Base(path))
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// ParsePeriod returns the open times covered by a daily or monthly archive, end excluded.
+func ParsePeriod(path string) (start, end int64, ok bool) {
+	match := archiveName.FindStringSubmatch(filepath.Base(path))
+	if match == nil {
+		return 0, 0, false
+	}
+	if len(match[3]) == len("2006-01") {
+		month, err := time.Parse("2006-01", match[3])
+		if err != nil {
+			return 0, 0, false
+		}
+		return month.UnixMilli(), month.AddDate(0, 1, 0).UnixMilli(), true
+	}
+	day, err := time.Parse("2006-01-02", match[3])
+	if err != nil {
+		return 0, 0, false
+	}
+	return day.UnixMilli(), day.AddDate(0, 0, 1).UnixMilli(), true
+}
+
+func IsArchive(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".zip" || ext == ".csv" || ext == ".parquet"
+}
+
+// VerifyChecksum compares path against the sha256 in <path>.CHECKSUM,
+// it reports false without error when there is no checksum file.
+func VerifyChecksum(path string) (bool, error) {
+	content, err := os.ReadFile(path + ".CHECKSUM")
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return false, fmt.Errorf("%s.CHECKSUM is empty", path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return false, err
+	}
+	if !strings.EqualFold(hex.EncodeToString(hash.Sum(nil)), fields[0]) {
+		return false, errChecksumMismatch
+	}
+	return true, nil
+}
+
+// ReadFile parses the klines of a Binance CSV file, of every CSV inside a zip, or of a Parquet file.
+func ReadFile(path string, handler func(kline *pgdb.PlaybackKline) error) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".parquet":
+		return ReadParquet(path, handler)
+	case ".zip":
+		return readZip(path, handler)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return ReadCSV(file, handler)
+}
+
+func readZip(path string, handler func(kline *pgdb.PlaybackKline) error) error {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	for _, entry := range reader.File {
+		if strings.ToLower(filepath.Ext(entry.Name)) != ".csv" {
+			continue
+		}
+		file, err := entry.Open()
+		if err != nil {
+			return err
+		}
+		err = ReadCSV(file, handler)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", entry.Name, err)
+		}
+	}
+	return nil
+}
+
+// ReadCSV parses Binance kline rows, skipping a header line if there is one.
+func ReadCSV(r io.Reader, handler func(kline *pgdb.PlaybackKline) error) error {
+	reader := csv.NewReader(bufio.NewReader(r))
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line++
+		if line == 1 && len(record) > 0 && !isNumber(record[0]) {
+			continue // Header
+		}
+		kline, err := parseRecord(record)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := handler(kline); err != nil {
+			return err
+		}
+	}
+}
+
+const parquetBatchSize = 64 * 1024
+
+// ReadParquet parses klines from a Parquet file with the columns of the Binance CSV header.
+// Time and count columns may be int32, int64 or timestamps, the others float or double.
+func ReadParquet(path string, handler func(kline *pgdb.PlaybackKline) error) error {
+	file, err := pqfile.OpenParquetFile(path, false)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	schema := file.MetaData().Schema
+	indices := []int{}
+	for _, name := range append(append([]string{}, intColumns...), floatColumns...) {
+		index := schema.ColumnIndexByName(name)
+		if index < 0 {
+			return fmt.Errorf("%w: %s", errColumnNotExist, name)
+		}
+		indices = append(indices, index)
+	}
+	hasIgnore := false
+	if index := schema.ColumnIndexByName("ignore"); index >= 0 { // Optional
+		indices = append(indices, index)
+		hasIgnore = true
+	}
+	// Every row group must hold a value per row in each column, the record reader assumes it
+	for group := 0; group < file.NumRowGroups(); group++ {
+		meta := file.MetaData().RowGroup(group)
+		for _, index := range indices {
+			chunk, err := meta.ColumnChunk(index)
+			if err != nil {
+				return err
+			}
+			if chunk.NumValues() != meta.NumRows() {
+				return fmt.Errorf("column %s has %d values in row group %d, expect %d", schema.Column(index).Name(), chunk.NumValues(), group, meta.NumRows())
+			}
+		}
+	}
+	reader, err := pqarrow.NewFileReader(file, pqarrow.ArrowReadProperties{BatchSize: parquetBatchSize}, memory.DefaultAllocator)
+	if err != nil {
+		return err
+	}
+	records, err := reader.GetRecordReader(context.Background(), indices, nil)
+	if err != nil {
+		return err
+	}
+	defer records.Release()
+	for records.Next() {
+		record := records.Record()
+		numRows := int(record.NumRows())
+		columns := make(map[string]arrow.Array, len(indices))
+		for i, column := range record.Columns() {
+			if column.NullN() > 0 {
+				return fmt.Errorf("column %s has null values", record.ColumnName(i))
+			}
+			columns[record.ColumnName(i)] = column
+		}
+		ints := make([]func(row int) int64, len(intColumns))
+		for i, name := range intColumns {
+			if ints[i], err = intValues(columns[name]); err != nil {
+				return fmt.Errorf("column %s: %w", name, err)
+			}
+		}
+		floats := make([]func(row int) float64, len(floatColumns))
+		for i, name := range floatColumns {
+			if floats[i], err = floatValues(columns[name]); err != nil {
+				return fmt.Errorf("column %s: %w", name, err)
+			}
+		}
+		var ignores func(row int) int64
+		if hasIgnore {
+			if ignores, err = intValues(columns["ignore"]); err != nil {
+				return fmt.Errorf("column ignore: %w", err)
+			}
+		}
+		for row := 0; row < numRows; row++ {
+			kline := &pgdb.PlaybackKline{
+				OpenTime:            toMilli(ints[0](row)),
+				Open:                floats[0](row),
+				High:                floats[1](row),
+				Low:                 floats[2](row),
+				Close:               floats[3](row),
+				Volume:              floats[4](row),
+				CloseTime:           toMilli(ints[1](row)),
+				QuoteVolume:         floats[5](row),
+				Count:               ints[2](row),
+				TakerBuyVolume:      floats[6](row),
+				TakerBuyQuoteVolume: floats[7](row),
+			}
+			if ignores != nil {
+				kline.Ignore = ignores(row)
+			}
+			if err := handler(kline); err != nil {
+				return err
+			}
+		}
+	}
+	if err := records.Err(); err != nil && err != io.EOF { // The reader reports the end of the file as io.EOF
+		return err
+	}
+	return nil
+}
+
+// intValues reads an integer column, timestamps are converted to milliseconds.
+func intValues(column arrow.Array) (func(row int) int64, error) {
+	switch values := column.(type) {
+	case *array.Int64:
+		return values.Value, nil
+	case *array.Int32:
+		return func(row int) int64 { return int64(values.Value(row)) }, nil
+	case *array.Timestamp:
+		toTime, err := values.DataType().(*arrow.TimestampType).GetToTimeFunc()
+		if err != nil {
+			return nil, err
+		}
+		return func(row int) int64 { return toTime(values.Value(row)).UnixMilli() }, nil
+	}
+	return nil, fmt.Errorf("%w: %s", errColumnNotNumeric, column.DataType())
+}
+
+func floatValues(column arrow.Array) (func(row int) float64, error) {
+	switch values := column.(type) {
+	case *array.Float64:
+		return values.Value, nil
+	case *array.Float32:
+		return func(row int) float64 { return float64(values.Value(row)) }, nil
+	}
+	return nil, fmt.Errorf("%w: %s", errColumnNotNumeric, column.DataType())
+}
+
+// Columns of the Binance kline header, in the order parseRecord and ReadParquet fill a kline
+var intColumns = []string{"open_time", "close_time", "count"}
+var floatColumns = []string{"open", "high", "low", "close", "volume", "quote_volume", "taker_buy_volume", "taker_buy_quote_volume"}
+
+func isNumber(field string) bool {
+	_, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+	return err == nil
+}
+
+func parseRecord(record []string) (*pgdb.PlaybackKline, error) {
+	if len(record) < 11 {
+		return nil, errColumnNotEnough
+	}
+	ints := make([]int64, 3)
+	for i, column := range []int{0, 6, 8} {
+		value, err := strconv.ParseInt(strings.TrimSpace(record[column]), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ints[i] = value
+	}
+	floats := make([]float64, 8)
+	for i, column := range []int{1, 2, 3, 4, 5, 7, 9, 10} {
+		value, err := strconv.ParseFloat(strings.TrimSpace(record[column]), 64)
+		if err != nil {
+			return nil, err
+		}
+		floats[i] = value
+	}
+	kline := &pgdb.PlaybackKline{
+		OpenTime:            toMilli(ints[0]),
+		Open:                floats[0],
+		High:                floats[1],
+		Low:                 floats[2],
+		Close:               floats[3],
+		Volume:              floats[4],
+		CloseTime:           toMilli(ints[1]),
+		QuoteVolume:         floats[5],
+		Count:               ints[2],
+		TakerBuyVolume:      floats[6],
+		TakerBuyQuoteVolume: floats[7],
+	}
+	if len(record) > 11 {
+		kline.Ignore, _ = strconv.ParseInt(strings.TrimSpace(record[11]), 10, 64)
+	}
+	return kline, nil
+}
+
+// toMilli normalizes timestamps, spot archives switched to microseconds in 2025.
+func toMilli(timestamp int64) int64 {
+	if timestamp > 1e14 {
+		return timestamp / 1000
+	}
+	return timestamp
+}
+
+var intervalMs = map[string]int64{
+	"1s":  1_000,
+	"1m":  60_000,
+	"3m":  180_000,
+	"5m":  300_000,
+	"15m": 900_000,
+	"30m": 1_800_000,
+	"1h":  3_600_000,
+	"2h":  7_200_000,
+	"4h":  14_400_000,
+	"6h":  21_600_000,
+	"8h":  28_800_000,
+	"12h": 43_200_000,
+	"1d":  86_400_000,
+	"3d":  259_200_000,
+	"1w":  604_800_000,
+}
+
+// IntervalMs returns the width of a Binance interval, false for intervals without a fixed width like 1M.
+func IntervalMs(interval string) (int64, bool) {
+	width, ok := intervalMs[interval]
+	return width, ok
+}
@@ -0,0 +1,75 @@
+package archive
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+)
+
+const sampleCSV = `open_time,open,high,low,close,volume,close_time,quote_volume,count,taker_buy_volume,taker_buy_quote_volume,ignore
+1682899200000,29230.00,29231.00,29229.00,29230.50,1.5,1682899200999,43845.75,12,0.7,20461.35,0
+1735689600000000,93576.00,93577.00,93575.00,93576.50,2.0,1735689600999999,187153.00,20,1.0,93576.50,0
+`
+
+func TestReadCSV(t *testing.T) {
+	klines := []pgdb.PlaybackKline{}
+	err := ReadCSV(strings.NewReader(sampleCSV), func(kline *pgdb.PlaybackKline) error {
+		klines = append(klines, *kline)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error reading csv: %v", err)
+	}
+	if len(klines) != 2 {
+		t.Fatalf("Expected 2 klines, got %d", len(klines))
+	}
+	if klines[0].OpenTime != 1682899200000 || klines[0].Close != 29230.5 || klines[0].Count != 12 || klines[0].TakerBuyQuoteVolume != 20461.35 {
+		t.Errorf("Unexpected first kline %+v", klines[0])
+	}
+	if klines[1].OpenTime != 1735689600000 || klines[1].CloseTime != 1735689600999 {
+		t.Errorf("Expected microseconds to become milliseconds, got %+v", klines[1])
+	}
+}
+
+func TestReadZipWithChecksum(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "BTCUSDT-1s-2023-05-01.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := zip.NewWriter(file)
+	entry, _ := writer.Create("BTCUSDT-1s-2023-05-01.csv")
+	entry.Write([]byte(sampleCSV))
+	writer.Close()
+	file.Close()
+
+	if isVerified, err := VerifyChecksum(path); isVerified || err != nil {
+		t.Errorf("Expected no checksum to verify, got %v %v", isVerified, err)
+	}
+	content, _ := os.ReadFile(path)
+	sum := sha256.Sum256(content)
+	os.WriteFile(path+".CHECKSUM", []byte(hex.EncodeToString(sum[:])+"  BTCUSDT-1s-2023-05-01.zip\n"), 0o644)
+	if isVerified, err := VerifyChecksum(path); !isVerified || err != nil {
+		t.Errorf("Expected checksum to verify, got %v %v", isVerified, err)
+	}
+	os.WriteFile(path+".CHECKSUM", []byte(strings.Repeat("0", 64)+"  BTCUSDT-1s-2023-05-01.zip\n"), 0o644)
+	if _, err := VerifyChecksum(path); err != errChecksumMismatch {
+		t.Errorf("Expected errChecksumMismatch, got %v", err)
+	}
+
+	n := 0
+	if err := ReadFile(path, func(kline *pgdb.PlaybackKline) error { n++; return nil }); err != nil || n != 2 {
+		t.Errorf("Expected 2 klines from zip, got %d: %v", n, err)
+	}
+	symbol, interval, ok := ParseName(path)
+	if !ok || symbol != "BTCUSDT" || interval != "1s" {
+		t.Errorf("Expected BTCUSDT 1s, got %s %s %v", symbol, interval, ok)
+	}
+}
@@ -0,0 +1,191 @@
+package archive
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+	log "github.com/sirupsen/logrus"
+)
+
+var errKlineNotExist = errors.New("kline is not existed")
+
+const maxCachedFiles = 4
+
+// archiveFile is an archive of the period opening at start, its klines open from first to last.
+type archiveFile struct {
+	path  string
+	start int64
+	first int64
+	last  int64
+	empty bool
+}
+
+// DirSource serves klines from the Binance-style archives (zip, csv or parquet) under a directory,
+// named like BTCUSDT-1s-2023-05.zip or BTCUSDT-1s-2023-05-01.parquet.
+type DirSource struct {
+	files map[string][]archiveFile // Keyed by SYMBOL/interval, sorted by start
+
+	cacheMutex sync.Mutex
+	cache      map[string][]pgdb.PlaybackKline
+	cacheOrder []string // Least recently used first
+}
+
+func NewDirSource(dir string) (*DirSource, error) {
+	src := &DirSource{
+		files: make(map[string][]archiveFile),
+		cache: make(map[string][]pgdb.PlaybackKline),
+	}
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !IsArchive(path) {
+			return nil
+		}
+		symbol, interval, ok := ParseName(path)
+		start, _, isDated := ParsePeriod(path)
+		if !ok || !isDated {
+			log.Warnf("Skip %s: name is not SYMBOL-interval-date", path)
+			return nil
+		}
+		file := archiveFile{path: path, start: start, empty: true}
+		// Decoded once here, so KlineRange does not read every archive again
+		err = ReadFile(path, func(kline *pgdb.PlaybackKline) error {
+			if file.empty || kline.OpenTime < file.first {
+				file.first = kline.OpenTime
+			}
+			if file.empty || kline.OpenTime > file.last {
+				file.last = kline.OpenTime
+			}
+			file.empty = false
+			return nil
+		})
+		if err != nil {
+			log.Warnf("Skip %s: %v", path, err)
+			return nil
+		}
+		key := sourceKey(symbol, interval)
+		src.files[key] = append(src.files[key], file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, files := range src.files {
+		sort.Slice(files, func(i, j int) bool {
+			if files[i].start != files[j].start {
+				return files[i].start < files[j].start
+			}
+			return files[i].path < files[j].path
+		})
+	}
+	return src, nil
+}
+
+func sourceKey(symbol, interval string) string {
+	if interval == "" {
+		interval = "1s"
+	}
+	return strings.ToUpper(symbol) + "/" + interval
+}
+
+// QueryKlines returns the klines opened in [startTime, endTime] ordered by open time,
+// a kline present in several archives, like a daily and a monthly one, is returned once.
+func (src *DirSource) QueryKlines(symbol, interval string, startTime, endTime int64) ([]pgdb.PlaybackKline, error) {
+	records := []pgdb.PlaybackKline{}
+	for _, file := range src.files[sourceKey(symbol, interval)] {
+		if file.empty || file.last < startTime || file.first > endTime {
+			continue
+		}
+		klines, err := src.load(file.path)
+		if err != nil {
+			return nil, err
+		}
+		first := sort.Search(len(klines), func(i int) bool { return klines[i].OpenTime >= startTime })
+		for _, kline := range klines[first:] {
+			if kline.OpenTime > endTime {
+				break
+			}
+			records = append(records, kline)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].OpenTime < records[j].OpenTime })
+	unique := records[:0]
+	for _, kline := range records {
+		if len(unique) > 0 && unique[len(unique)-1].OpenTime == kline.OpenTime {
+			continue
+		}
+		unique = append(unique, kline)
+	}
+	return unique, nil
+}
+
+func (src *DirSource) KlineRange(symbol, interval string) (int64, int64, error) {
+	first, last := int64(0), int64(0)
+	for _, file := range src.files[sourceKey(symbol, interval)] {
+		if file.empty {
+			continue
+		}
+		if first == 0 || file.first < first {
+			first = file.first
+		}
+		if file.last > last {
+			last = file.last
+		}
+	}
+	if first == 0 {
+		return 0, 0, errKlineNotExist
+	}
+	return first, last, nil
+}
+
+// load decodes an archive, keeping the most recently used ones in memory. Decoding runs
+// outside the lock, so a query on a cached archive is not held up by one being decoded.
+func (src *DirSource) load(path string) ([]pgdb.PlaybackKline, error) {
+	src.cacheMutex.Lock()
+	if klines, ok := src.cache[path]; ok {
+		src.touch(path)
+		src.cacheMutex.Unlock()
+		return klines, nil
+	}
+	src.cacheMutex.Unlock()
+
+	klines := []pgdb.PlaybackKline{}
+	err := ReadFile(path, func(kline *pgdb.PlaybackKline) error {
+		klines = append(klines, *kline)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(klines, func(i, j int) bool { return klines[i].OpenTime < klines[j].OpenTime })
+
+	src.cacheMutex.Lock()
+	defer src.cacheMutex.Unlock()
+	if cached, ok := src.cache[path]; ok { // Decoded meanwhile by another query
+		src.touch(path)
+		return cached, nil
+	}
+	if len(src.cacheOrder) >= maxCachedFiles {
+		delete(src.cache, src.cacheOrder[0])
+		src.cacheOrder = src.cacheOrder[1:]
+	}
+	src.cache[path] = klines
+	src.cacheOrder = append(src.cacheOrder, path)
+	return klines, nil
+}
+
+func (src *DirSource) touch(path string) {
+	for i, cached := range src.cacheOrder {
+		if cached == path {
+			src.cacheOrder = append(src.cacheOrder[:i], src.cacheOrder[i+1:]...)
+			break
+		}
+	}
+	src.cacheOrder = append(src.cacheOrder, path)
+}
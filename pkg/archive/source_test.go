@@ -0,0 +1,108 @@
+package archive
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+)
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+	// A monthly parquet and a daily csv overlapping on the second kline of May 01 2023
+	openTimes := []int64{1682899200000, 1682899201000, 1682899202000}
+	columns := map[string][]int64{
+		"open_time":  openTimes,
+		"close_time": {1682899200999, 1682899201999, 1682899202999},
+		"count":      {1, 2, 3},
+	}
+	writeParquet(t, filepath.Join(dir, "BTCUSDT-1s-2023-05.parquet"), columns, floatColumns)
+	daily := "1682899201000,2,2,2,2,2,1682899201999,2,2,2,2,0\n1682899203000,4,4,4,4,4,1682899203999,4,4,4,4,0\n"
+	os.WriteFile(filepath.Join(dir, "BTCUSDT-1s-2023-05-01.csv"), []byte(daily), 0o644)
+	os.WriteFile(filepath.Join(dir, "README.txt"), []byte("not an archive"), 0o644)
+
+	src, err := NewDirSource(dir)
+	if err != nil {
+		t.Fatalf("Error indexing: %v", err)
+	}
+	klines, err := src.QueryKlines("btcusdt", "1s", 1682899201000, 1682899203000)
+	if err != nil {
+		t.Fatalf("Error querying: %v", err)
+	}
+	expected := []int64{1682899201000, 1682899202000, 1682899203000}
+	if len(klines) != len(expected) {
+		t.Fatalf("Expected %d klines, got %+v", len(expected), klines)
+	}
+	for i, openTime := range expected {
+		if klines[i].OpenTime != openTime || klines[i].Close != float64(i+2) {
+			t.Errorf("Expected kline %d at %d, got %+v", i, openTime, klines[i])
+		}
+	}
+	first, last, err := src.KlineRange("BTCUSDT", "1s")
+	if err != nil || first != 1682899200000 || last != 1682899203000 {
+		t.Errorf("Unexpected range %d %d %v", first, last, err)
+	}
+	if _, _, err := src.KlineRange("ETHUSDT", "1s"); err == nil {
+		t.Errorf("Expected no range of a missing symbol")
+	}
+}
+
+func TestReadParquetMissingColumn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "BTCUSDT-1s-2023-05.parquet")
+	writeParquet(t, path, map[string][]int64{"open_time": {1682899200000}, "close_time": {1682899200999}, "count": {1}}, floatColumns[:3])
+	err := ReadParquet(path, func(kline *pgdb.PlaybackKline) error { return nil })
+	if !errors.Is(err, errColumnNotExist) {
+		t.Errorf("Expected a missing column error, got %v", err)
+	}
+}
+
+// writeParquet writes int64 columns and float columns valued 1, 2, 3... as a Parquet file
+// without ignore column, like one written by pandas from a trimmed CSV.
+func writeParquet(t *testing.T, path string, ints map[string][]int64, floats []string) {
+	fields := []arrow.Field{}
+	columns := []arrow.Array{}
+	numRows := 0
+	for _, name := range intColumns {
+		values, ok := ints[name]
+		if !ok {
+			continue
+		}
+		builder := array.NewInt64Builder(memory.DefaultAllocator)
+		builder.AppendValues(values, nil)
+		fields = append(fields, arrow.Field{Name: name, Type: arrow.PrimitiveTypes.Int64})
+		columns = append(columns, builder.NewArray())
+		numRows = len(values)
+	}
+	for _, name := range floats {
+		builder := array.NewFloat64Builder(memory.DefaultAllocator)
+		for i := 0; i < numRows; i++ {
+			builder.Append(float64(i + 1))
+		}
+		fields = append(fields, arrow.Field{Name: name, Type: arrow.PrimitiveTypes.Float64})
+		columns = append(columns, builder.NewArray())
+	}
+	record := array.NewRecord(arrow.NewSchema(fields, nil), columns, int64(numRows))
+	defer record.Release()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	writer, err := pqarrow.NewFileWriter(record.Schema(), file, nil, pqarrow.DefaultWriterProps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,322 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/ipc"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet"
+	"github.com/apache/arrow/go/v17/parquet/compress"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+)
+
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatParquet Format = "parquet"
+	FormatArrow   Format = "arrow"
+)
+
+// batchSize is the rows per Parquet row group or Arrow record batch.
+const batchSize = 100_000
+
+const dayMs = int64(24 * time.Hour / time.Millisecond)
+
+// header is the Binance kline archive header, so exports can be imported or played back again.
+var header = []string{"open_time", "open", "high", "low", "close", "volume", "close_time", "quote_volume", "count", "taker_buy_volume", "taker_buy_quote_volume", "ignore"}
+
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case FormatCSV, FormatParquet, FormatArrow:
+		return format, nil
+	}
+	return "", fmt.Errorf("export format %s is not existed, use csv, parquet or arrow", name)
+}
+
+// FileName names an export after the Binance archives, BTCUSDT-1m-2023-05-01.parquet for the UTC day
+// starting at day, or BTCUSDT-1m.parquet for a whole range when day is -1.
+func FileName(symbol, interval string, day int64, format Format) string {
+	name := strings.ToUpper(symbol) + "-" + interval
+	if day >= 0 {
+		name += "-" + time.UnixMilli(day).UTC().Format("2006-01-02")
+	}
+	return name + "." + string(format)
+}
+
+// klineWriter encodes klines into one export file, a batch at a time.
+type klineWriter interface {
+	write(klines []pgdb.PlaybackKline) error
+	close() error
+}
+
+func newKlineWriter(w io.Writer, format Format) (klineWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w)
+	case FormatParquet:
+		props := parquet.NewWriterProperties(
+			parquet.WithCompression(compress.Codecs.Snappy),
+			parquet.WithMaxRowGroupLength(batchSize),
+		)
+		// The parquet writer closes a sink that is an io.Closer, the file is closed by the Exporter
+		writer, err := pqarrow.NewFileWriter(klineSchema, struct{ io.Writer }{w}, props, pqarrow.DefaultWriterProps())
+		if err != nil {
+			return nil, err
+		}
+		return &recordWriter{writeRecord: writer.Write, closer: writer.Close}, nil
+	case FormatArrow:
+		seeker, ok := w.(io.WriteSeeker)
+		if !ok {
+			return nil, errors.New("arrow export needs a seekable writer")
+		}
+		writer, err := ipc.NewFileWriter(seeker, ipc.WithSchema(klineSchema))
+		if err != nil {
+			return nil, err
+		}
+		return &recordWriter{writeRecord: writer.Write, closer: writer.Close}, nil
+	}
+	return nil, fmt.Errorf("export format %s is not existed", format)
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	return &csvWriter{writer: writer}, nil
+}
+
+func (cw *csvWriter) write(klines []pgdb.PlaybackKline) error {
+	formatFloat := func(value float64) string { return strconv.FormatFloat(value, 'f', -1, 64) }
+	for _, kline := range klines {
+		record := []string{
+			strconv.FormatInt(kline.OpenTime, 10),
+			formatFloat(kline.Open),
+			formatFloat(kline.High),
+			formatFloat(kline.Low),
+			formatFloat(kline.Close),
+			formatFloat(kline.Volume),
+			strconv.FormatInt(kline.CloseTime, 10),
+			formatFloat(kline.QuoteVolume),
+			strconv.FormatInt(kline.Count, 10),
+			formatFloat(kline.TakerBuyVolume),
+			formatFloat(kline.TakerBuyQuoteVolume),
+			strconv.FormatInt(kline.Ignore, 10),
+		}
+		if err := cw.writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cw *csvWriter) close() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+// recordWriter writes every batch as one Arrow record, a Parquet row group or an Arrow IPC record batch.
+type recordWriter struct {
+	writeRecord func(record arrow.Record) error
+	closer      func() error
+}
+
+func (rw *recordWriter) write(klines []pgdb.PlaybackKline) error {
+	record := klineRecord(klines)
+	defer record.Release()
+	return rw.writeRecord(record)
+}
+
+func (rw *recordWriter) close() error {
+	return rw.closer()
+}
+
+var timestampType = &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}
+
+// klineSchema has the columns of header, ints for times and counts, floats otherwise.
+var klineSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "open_time", Type: timestampType},
+	{Name: "open", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "high", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "low", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "close", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "volume", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "close_time", Type: timestampType},
+	{Name: "quote_volume", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "count", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "taker_buy_volume", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "taker_buy_quote_volume", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "ignore", Type: arrow.PrimitiveTypes.Int64},
+}, nil)
+
+func klineRecord(klines []pgdb.PlaybackKline) arrow.Record {
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, klineSchema)
+	defer builder.Release()
+	builder.Reserve(len(klines))
+	timestamp := func(i int) *array.TimestampBuilder { return builder.Field(i).(*array.TimestampBuilder) }
+	float := func(i int) *array.Float64Builder { return builder.Field(i).(*array.Float64Builder) }
+	integer := func(i int) *array.Int64Builder { return builder.Field(i).(*array.Int64Builder) }
+	for i := range klines {
+		kline := &klines[i]
+		timestamp(0).Append(arrow.Timestamp(kline.OpenTime))
+		float(1).Append(kline.Open)
+		float(2).Append(kline.High)
+		float(3).Append(kline.Low)
+		float(4).Append(kline.Close)
+		float(5).Append(kline.Volume)
+		timestamp(6).Append(arrow.Timestamp(kline.CloseTime))
+		float(7).Append(kline.QuoteVolume)
+		integer(8).Append(kline.Count)
+		float(9).Append(kline.TakerBuyVolume)
+		float(10).Append(kline.TakerBuyQuoteVolume)
+		integer(11).Append(kline.Ignore)
+	}
+	return builder.NewRecord()
+}
+
+// Exporter writes the klines of one symbol and interval under a directory, one file per UTC day
+// when byDay is set, otherwise one file for everything added. Klines are written out every
+// batchSize rows, so only one batch is held in memory.
+type Exporter struct {
+	dir      string
+	symbol   string
+	interval string
+	format   Format
+	byDay    bool
+
+	day          int64 // Start of the day being written
+	lastOpenTime int64
+	file         *os.File // Temporary file being written, renamed on close
+	writer       klineWriter
+	klines       []pgdb.PlaybackKline // Batch not written yet
+	files        []string
+}
+
+func NewExporter(dir, symbol, interval string, format Format, byDay bool) *Exporter {
+	return &Exporter{
+		dir:      dir,
+		symbol:   symbol,
+		interval: interval,
+		format:   format,
+		byDay:    byDay,
+	}
+}
+
+// Add writes a kline, klines come in open time order and a repeated or older one is dropped.
+// A kline of a new day closes the file of the previous day. On error the file being written
+// is discarded.
+func (e *Exporter) Add(kline *pgdb.PlaybackKline) error {
+	if e.lastOpenTime != 0 && kline.OpenTime <= e.lastOpenTime {
+		return nil
+	}
+	e.lastOpenTime = kline.OpenTime
+	day := kline.OpenTime - kline.OpenTime%dayMs
+	if e.byDay && e.file != nil && day != e.day {
+		if err := e.closeFile(); err != nil {
+			return err
+		}
+	}
+	if e.file == nil {
+		if err := e.openFile(day); err != nil {
+			return err
+		}
+	}
+	e.klines = append(e.klines, *kline)
+	if len(e.klines) >= batchSize {
+		return e.flush()
+	}
+	return nil
+}
+
+// Close writes out the buffered klines and closes the file being written.
+func (e *Exporter) Close() error {
+	if e.file == nil {
+		return nil
+	}
+	return e.closeFile()
+}
+
+// Discard drops the file being written, e.g. when reading the klines failed halfway.
+func (e *Exporter) Discard() {
+	if e.file == nil {
+		return
+	}
+	e.file.Close()
+	os.Remove(e.file.Name())
+	e.file, e.writer, e.klines = nil, nil, e.klines[:0]
+}
+
+func (e *Exporter) Files() []string {
+	return e.files
+}
+
+func (e *Exporter) openFile(day int64) error {
+	// Write aside and rename, a failed export leaves no truncated file behind
+	file, err := os.CreateTemp(e.dir, ".export-*")
+	if err != nil {
+		return err
+	}
+	writer, err := newKlineWriter(file, e.format)
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	e.day, e.file, e.writer = day, file, writer
+	return nil
+}
+
+func (e *Exporter) flush() error {
+	if len(e.klines) == 0 {
+		return nil
+	}
+	if err := e.writer.write(e.klines); err != nil {
+		e.Discard()
+		return err
+	}
+	e.klines = e.klines[:0]
+	return nil
+}
+
+func (e *Exporter) closeFile() error {
+	if err := e.flush(); err != nil {
+		return err
+	}
+	if err := e.writer.close(); err != nil {
+		e.Discard()
+		return err
+	}
+	day := int64(-1)
+	if e.byDay {
+		day = e.day
+	}
+	path := filepath.Join(e.dir, FileName(e.symbol, e.interval, day, e.format))
+	file := e.file
+	e.file, e.writer = nil, nil
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	e.files = append(e.files, path)
+	return nil
+}
@@ -0,0 +1,130 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+	"github.com/BullionBear/crypto-feed/pkg/archive"
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/ipc"
+	pqfile "github.com/apache/arrow/go/v17/parquet/file"
+)
+
+func TestExporterByDay(t *testing.T) {
+	// Two klines at the end of May 01 2023 and one at the start of May 02, plus a repeat
+	openTimes := []int64{1682985598000, 1682985599000, 1682985599000, 1682985600000}
+	for _, format := range []Format{FormatCSV, FormatParquet} {
+		dir := t.TempDir()
+		exporter := NewExporter(dir, "btcusdt", "1s", format, true)
+		for i, openTime := range openTimes {
+			kline := pgdb.PlaybackKline{OpenTime: openTime, CloseTime: openTime + 999, Close: float64(i), Count: int64(i)}
+			if err := exporter.Add(&kline); err != nil {
+				t.Fatalf("Error adding: %v", err)
+			}
+		}
+		if err := exporter.Close(); err != nil {
+			t.Fatalf("Error closing: %v", err)
+		}
+		expected := []string{
+			filepath.Join(dir, "BTCUSDT-1s-2023-05-01."+string(format)),
+			filepath.Join(dir, "BTCUSDT-1s-2023-05-02."+string(format)),
+		}
+		files := exporter.Files()
+		if len(files) != 2 || files[0] != expected[0] || files[1] != expected[1] {
+			t.Fatalf("Expected files %v, got %v", expected, files)
+		}
+
+		// Exports read back as archives
+		src, err := archive.NewDirSource(dir)
+		if err != nil {
+			t.Fatalf("Error indexing: %v", err)
+		}
+		klines, err := src.QueryKlines("BTCUSDT", "1s", openTimes[0], openTimes[3])
+		if err != nil {
+			t.Fatalf("Error reading %s: %v", format, err)
+		}
+		if len(klines) != 3 {
+			t.Fatalf("Expected 3 klines in %s, got %+v", format, klines)
+		}
+		if klines[1].Close != 1 || klines[2].CloseTime != 1682985600999 || klines[2].Count != 3 {
+			t.Errorf("Unexpected klines in %s: %+v", format, klines)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if format, err := ParseFormat("Arrow"); err != nil || format != FormatArrow {
+		t.Errorf("Expected arrow, got %s %v", format, err)
+	}
+	if _, err := ParseFormat("xlsx"); err == nil {
+		t.Errorf("Expected xlsx to be rejected")
+	}
+	if name := FileName("ethusdt", "1h", -1, FormatArrow); name != "ETHUSDT-1h.arrow" {
+		t.Errorf("Unexpected name %s", name)
+	}
+}
+
+func TestExporterBatches(t *testing.T) {
+	// Two full batches and one partial, written as three row groups or record batches
+	nRows := 2*batchSize + 1
+	for _, format := range []Format{FormatParquet, FormatArrow} {
+		dir := t.TempDir()
+		exporter := NewExporter(dir, "BTCUSDT", "1s", format, false)
+		for i := 0; i < nRows; i++ {
+			openTime := 1682899200000 + int64(i)*1000
+			kline := pgdb.PlaybackKline{OpenTime: openTime, CloseTime: openTime + 999, Close: float64(i), Count: int64(i)}
+			if err := exporter.Add(&kline); err != nil {
+				t.Fatalf("Error adding: %v", err)
+			}
+			if n := len(exporter.klines); n >= batchSize {
+				t.Fatalf("Expected at most one batch in memory, got %d klines", n)
+			}
+		}
+		if err := exporter.Close(); err != nil {
+			t.Fatalf("Error closing: %v", err)
+		}
+		files := exporter.Files()
+		if len(files) != 1 || files[0] != filepath.Join(dir, "BTCUSDT-1s."+string(format)) {
+			t.Fatalf("Unexpected files %v", files)
+		}
+		nBatches, rows := readBatches(t, files[0], format)
+		if nBatches != 3 || rows != int64(nRows) {
+			t.Errorf("Expected 3 batches of %d rows in %s, got %d of %d", nRows, format, nBatches, rows)
+		}
+	}
+}
+
+func readBatches(t *testing.T, path string, format Format) (int, int64) {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if format == FormatParquet {
+		reader, err := pqfile.NewParquetReader(file)
+		if err != nil {
+			t.Fatalf("Error reading parquet: %v", err)
+		}
+		defer reader.Close()
+		return reader.NumRowGroups(), reader.NumRows()
+	}
+	reader, err := ipc.NewFileReader(file)
+	if err != nil {
+		t.Fatalf("Error reading arrow: %v", err)
+	}
+	defer reader.Close()
+	rows := int64(0)
+	for i := 0; i < reader.NumRecords(); i++ {
+		record, err := reader.Record(i)
+		if err != nil {
+			t.Fatalf("Error reading record %d: %v", i, err)
+		}
+		if !arrow.TypeEqual(record.Schema().Field(0).Type, timestampType) {
+			t.Errorf("Expected open_time as a UTC millisecond timestamp, got %s", record.Schema().Field(0).Type)
+		}
+		rows += record.NumRows()
+	}
+	return reader.NumRecords(), rows
+}
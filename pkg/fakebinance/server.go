@@ -0,0 +1,348 @@
+package fakebinance
+
+/*
+Server is a fake Binance spot exchange serving 1s klines over REST and websocket,
+so KLineService can be exercised end to end without reaching the real exchange.
+It also streams a USD-M mark price built from the same klines.
+*/
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/BullionBear/crypto-feed/pkg/service"
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	klineInterval = "1s"
+	intervalMs    = int64(1000)
+	defaultLimit  = 500
+	maxLimit      = 1000
+	fundingMs     = int64(8 * 3_600_000)
+	fundingRate   = "0.00010000"
+)
+
+type Options struct {
+	StartTime       int64 // Virtual clock start in ms, 0 follows the wall clock
+	DisconnectEvery int   // Close the websocket after every N pushed klines, 0 disables
+	DuplicateEvery  int   // Push every Nth kline twice on the websocket, 0 disables
+	GapEvery        int   // Skip every Nth kline on the websocket, 0 disables
+	RateLimitEvery  int   // Reject every Nth REST request with HTTP 429, 0 disables
+	PartialUpdates  bool  // Also push the kline in progress on every websocket tick
+}
+
+type Server struct {
+	opts      Options
+	source    Source
+	startWall time.Time
+	nRequest  atomic.Int64
+	upgrader  websocket.Upgrader
+	mux       *http.ServeMux
+}
+
+func NewServer(source Source, opts Options) *Server {
+	s := &Server{
+		opts:      opts,
+		source:    source,
+		startWall: time.Now(),
+		mux:       http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/api/v3/klines", s.handleKlines)
+	s.mux.HandleFunc("/ws/", s.handleStream)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) Now() int64 {
+	if s.opts.StartTime == 0 {
+		return time.Now().UnixMilli()
+	}
+	return s.opts.StartTime + time.Since(s.startWall).Milliseconds()
+}
+
+// lastClosed returns the open time of the latest finished kline.
+func (s *Server) lastClosed() int64 {
+	return s.Now()/intervalMs*intervalMs - intervalMs
+}
+
+func (s *Server) handleKlines(w http.ResponseWriter, r *http.Request) {
+	if every := int64(s.opts.RateLimitEvery); every > 0 && s.nRequest.Add(1)%every == 0 {
+		writeError(w, http.StatusTooManyRequests, -1003, "Too many requests; fake rate limit.")
+		return
+	}
+	query := r.URL.Query()
+	symbol := strings.ToUpper(query.Get("symbol"))
+	if symbol == "" {
+		writeError(w, http.StatusBadRequest, -1102, "Mandatory parameter 'symbol' was not sent.")
+		return
+	}
+	if query.Get("interval") != klineInterval {
+		writeError(w, http.StatusBadRequest, -1120, "Invalid interval.")
+		return
+	}
+	limit, err := parseInt(query.Get("limit"), defaultLimit)
+	if err != nil || limit <= 0 || limit > maxLimit {
+		writeError(w, http.StatusBadRequest, -1100, "Illegal characters found in parameter 'limit'.")
+		return
+	}
+	startTime, errStart := parseInt(query.Get("startTime"), -1)
+	endTime, errEnd := parseInt(query.Get("endTime"), s.lastClosed())
+	if errStart != nil || errEnd != nil {
+		writeError(w, http.StatusBadRequest, -1100, "Illegal characters found in parameter 'startTime' or 'endTime'.")
+		return
+	}
+	if endTime > s.lastClosed() {
+		endTime = s.lastClosed()
+	}
+
+	klines := []service.Kline{}
+	if startTime >= 0 {
+		for openTime := (startTime + intervalMs - 1) / intervalMs * intervalMs; openTime <= endTime && len(klines) < int(limit); openTime += intervalMs {
+			if kline, exists := s.source.Kline(symbol, openTime); exists {
+				klines = append(klines, kline)
+			}
+		}
+	} else {
+		// Without startTime Binance returns the latest klines up to endTime
+		for openTime := endTime / intervalMs * intervalMs; openTime > endTime-limit*intervalMs; openTime -= intervalMs {
+			if kline, exists := s.source.Kline(symbol, openTime); exists {
+				klines = append([]service.Kline{kline}, klines...)
+			}
+		}
+	}
+
+	rows := make([][]interface{}, 0, len(klines))
+	for _, kline := range klines {
+		rows = append(rows, []interface{}{
+			kline.OpenTime,
+			formatFloat(kline.Open),
+			formatFloat(kline.High),
+			formatFloat(kline.Low),
+			formatFloat(kline.Close),
+			formatFloat(kline.Volume),
+			kline.CloseTime,
+			formatFloat(kline.QuoteAssetVolume),
+			kline.TradeNum,
+			formatFloat(kline.TakerBuyBaseAssetVolume),
+			formatFloat(kline.TakerBuyQuoteAssetVolume),
+			"0",
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rows)
+}
+
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	// Stream names look like btcusdt@kline_1s or btcusdt@markPrice@1s
+	stream := strings.TrimPrefix(r.URL.Path, "/ws/")
+	if symbol, rate, found := strings.Cut(stream, "@markPrice"); found && (rate == "" || rate == "@1s") {
+		s.handleMarkPrice(w, r, strings.ToUpper(symbol))
+		return
+	}
+	symbol, interval, found := strings.Cut(stream, "@kline_")
+	if !found || interval != klineInterval {
+		http.Error(w, "unknown stream "+stream, http.StatusNotFound)
+		return
+	}
+	symbol = strings.ToUpper(symbol)
+	conn, closedCh, err := s.upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	nPushed := 0
+	lastPushed := s.lastClosed() - intervalMs
+	for {
+		select {
+		case <-closedCh:
+			return
+		case <-ticker.C:
+		}
+		for openTime := lastPushed + intervalMs; openTime <= s.lastClosed(); openTime += intervalMs {
+			lastPushed = openTime
+			kline, exists := s.source.Kline(symbol, openTime)
+			if !exists {
+				continue
+			}
+			nPushed++
+			if s.opts.GapEvery > 0 && nPushed%s.opts.GapEvery == 0 {
+				continue
+			}
+			nSend := 1
+			if s.opts.DuplicateEvery > 0 && nPushed%s.opts.DuplicateEvery == 0 {
+				nSend = 2
+			}
+			for i := 0; i < nSend; i++ {
+				if err := conn.WriteJSON(newWsKlineEvent(symbol, &kline, true, s.Now())); err != nil {
+					log.Warnf("fail to push kline: %s", err.Error())
+					return
+				}
+			}
+			if s.opts.DisconnectEvery > 0 && nPushed%s.opts.DisconnectEvery == 0 {
+				log.Infof("fake disconnect of %s after %d klines", stream, nPushed)
+				return
+			}
+		}
+		if !s.opts.PartialUpdates {
+			continue
+		}
+		if kline, exists := s.source.Kline(symbol, lastPushed+intervalMs); exists {
+			if err := conn.WriteJSON(newWsKlineEvent(symbol, &kline, false, s.Now())); err != nil {
+				log.Warnf("fail to push kline: %s", err.Error())
+				return
+			}
+		}
+	}
+}
+
+// handleMarkPrice pushes the close of the latest finished kline as mark and index price every second.
+func (s *Server) handleMarkPrice(w http.ResponseWriter, r *http.Request, symbol string) {
+	conn, closedCh, err := s.upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closedCh:
+			return
+		case <-ticker.C:
+		}
+		kline, exists := s.source.Kline(symbol, s.lastClosed())
+		if !exists {
+			continue
+		}
+		now := s.Now()
+		price := formatFloat(kline.Close)
+		if err := conn.WriteJSON(&wsMarkPriceEvent{
+			Event:                "markPriceUpdate",
+			Time:                 now,
+			Symbol:               symbol,
+			MarkPrice:            price,
+			IndexPrice:           price,
+			EstimatedSettlePrice: price,
+			FundingRate:          fundingRate,
+			NextFundingTime:      now/fundingMs*fundingMs + fundingMs,
+		}); err != nil {
+			log.Warnf("fail to push mark price: %s", err.Error())
+			return
+		}
+	}
+}
+
+// upgrade accepts a websocket, closedCh is closed once the client hangs up.
+func (s *Server) upgrade(w http.ResponseWriter, r *http.Request) (conn *websocket.Conn, closedCh chan struct{}, err error) {
+	conn, err = s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Errorf("fail to upgrade websocket: %s", err.Error())
+		return nil, nil, err
+	}
+	closedCh = make(chan struct{})
+	go func() {
+		defer close(closedCh)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	return conn, closedCh, nil
+}
+
+type wsMarkPriceEvent struct {
+	Event                string `json:"e"`
+	Time                 int64  `json:"E"`
+	Symbol               string `json:"s"`
+	MarkPrice            string `json:"p"`
+	IndexPrice           string `json:"i"`
+	EstimatedSettlePrice string `json:"P"`
+	FundingRate          string `json:"r"`
+	NextFundingTime      int64  `json:"T"`
+}
+
+type wsKline struct {
+	StartTime            int64  `json:"t"`
+	EndTime              int64  `json:"T"`
+	Symbol               string `json:"s"`
+	Interval             string `json:"i"`
+	FirstTradeID         int64  `json:"f"`
+	LastTradeID          int64  `json:"L"`
+	Open                 string `json:"o"`
+	Close                string `json:"c"`
+	High                 string `json:"h"`
+	Low                  string `json:"l"`
+	Volume               string `json:"v"`
+	TradeNum             int64  `json:"n"`
+	IsFinal              bool   `json:"x"`
+	QuoteVolume          string `json:"q"`
+	ActiveBuyVolume      string `json:"V"`
+	ActiveBuyQuoteVolume string `json:"Q"`
+}
+
+type wsKlineEvent struct {
+	Event  string  `json:"e"`
+	Time   int64   `json:"E"`
+	Symbol string  `json:"s"`
+	Kline  wsKline `json:"k"`
+}
+
+func newWsKlineEvent(symbol string, kline *service.Kline, isFinal bool, eventTime int64) *wsKlineEvent {
+	return &wsKlineEvent{
+		Event:  "kline",
+		Time:   eventTime,
+		Symbol: symbol,
+		Kline: wsKline{
+			StartTime:            kline.OpenTime,
+			EndTime:              kline.CloseTime,
+			Symbol:               symbol,
+			Interval:             klineInterval,
+			FirstTradeID:         -1,
+			LastTradeID:          -1,
+			Open:                 formatFloat(kline.Open),
+			Close:                formatFloat(kline.Close),
+			High:                 formatFloat(kline.High),
+			Low:                  formatFloat(kline.Low),
+			Volume:               formatFloat(kline.Volume),
+			TradeNum:             kline.TradeNum,
+			IsFinal:              isFinal,
+			QuoteVolume:          formatFloat(kline.QuoteAssetVolume),
+			ActiveBuyVolume:      formatFloat(kline.TakerBuyBaseAssetVolume),
+			ActiveBuyQuoteVolume: formatFloat(kline.TakerBuyQuoteAssetVolume),
+		},
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code int64, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"code": code,
+		"msg":  msg,
+	})
+}
+
+func parseInt(s string, fallback int64) (int64, error) {
+	if s == "" {
+		return fallback, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 8, 64)
+}
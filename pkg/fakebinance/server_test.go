@@ -0,0 +1,145 @@
+package fakebinance
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BullionBear/crypto-feed/pkg/service"
+)
+
+func newTestExchange(t *testing.T, opts Options) (*Server, service.Exchange) {
+	server := NewServer(NewGeneratedSource(42), opts)
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws"
+	return server, service.NewBinanceExchange(ts.URL, wsURL)
+}
+
+func TestGeneratedSourceIsDeterministic(t *testing.T) {
+	a, _ := NewGeneratedSource(1).Kline("BTCUSDT", 1_700_000_000_000)
+	b, _ := NewGeneratedSource(1).Kline("BTCUSDT", 1_700_000_000_000)
+	if a != b {
+		t.Errorf("Expected identical klines, got %+v and %+v", a, b)
+	}
+	if a.High < a.Open || a.High < a.Close || a.Low > a.Open || a.Low > a.Close {
+		t.Errorf("Inconsistent OHLC %+v", a)
+	}
+}
+
+func TestKlinePage(t *testing.T) {
+	server, exchange := newTestExchange(t, Options{StartTime: 1_700_000_100_000})
+	klines, err := exchange.KlinePage(context.Background(), "BTCUSDT", "1s", 1_700_000_000_000, 1_700_000_009_999, 1000)
+	if err != nil {
+		t.Fatalf("Error fetching page: %v", err)
+	}
+	if len(klines) != 10 || klines[0].OpenTime != 1_700_000_000_000 || klines[9].OpenTime != 1_700_000_009_000 {
+		t.Errorf("Unexpected page of %d klines", len(klines))
+	}
+	recent, err := exchange.RecentKlines(context.Background(), "BTCUSDT", "1s", 5)
+	if err != nil {
+		t.Fatalf("Error fetching recent klines: %v", err)
+	}
+	if len(recent) != 5 || recent[4].OpenTime > server.Now()-1000 {
+		t.Errorf("Unexpected recent klines %+v", recent)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	_, exchange := newTestExchange(t, Options{RateLimitEvery: 2})
+	if _, err := exchange.RecentKlines(context.Background(), "BTCUSDT", "1s", 5); err != nil {
+		t.Errorf("Expected first request to pass, got %v", err)
+	}
+	if _, err := exchange.RecentKlines(context.Background(), "BTCUSDT", "1s", 5); err == nil {
+		t.Errorf("Expected second request to be rate limited")
+	}
+}
+
+func TestStreamInjection(t *testing.T) {
+	_, exchange := newTestExchange(t, Options{DuplicateEvery: 2, GapEvery: 3, DisconnectEvery: 4})
+	var openTimes []int64
+	doneC, _, err := exchange.KlineStream("BTCUSDT", "1s", func(kline *service.Kline) {
+		openTimes = append(openTimes, kline.OpenTime)
+	}, func(err error) {})
+	if err != nil {
+		t.Fatalf("Error opening stream: %v", err)
+	}
+	select {
+	case <-doneC:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected the stream to be disconnected")
+	}
+	// Klines 1..4: #2 and #4 are duplicated, #3 is skipped, disconnect after #4
+	if len(openTimes) != 5 || openTimes[1] != openTimes[2] || openTimes[3] != openTimes[4] || openTimes[3]-openTimes[2] != 2000 {
+		t.Errorf("Unexpected stream %v", openTimes)
+	}
+}
+
+func TestMarkPriceStream(t *testing.T) {
+	server := NewServer(NewGeneratedSource(42), Options{})
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	exchange := service.NewBinanceFuturesExchange(ts.URL, "ws"+strings.TrimPrefix(ts.URL, "http")+"/ws")
+	markCh := make(chan *service.MarkPriceUpdate, 1)
+	doneC, stopC, err := exchange.MarkPriceStream("BTCUSDT", func(markPrice *service.MarkPriceUpdate) {
+		select {
+		case markCh <- markPrice:
+		default:
+		}
+	}, func(err error) {})
+	if err != nil {
+		t.Fatalf("Error opening stream: %v", err)
+	}
+	defer func() {
+		close(stopC)
+		<-doneC
+	}()
+	select {
+	case markPrice := <-markCh:
+		if markPrice.MarkPrice <= 0 || markPrice.FundingRate != 0.0001 || markPrice.NextFundingTime%(8*3_600_000) != 0 {
+			t.Errorf("Unexpected mark price %+v", markPrice)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected a mark price update")
+	}
+}
+
+func TestKLineServiceAgainstFakeExchange(t *testing.T) {
+	_, exchange := newTestExchange(t, Options{})
+	srv := service.NewKLineService("BTCUSDT", 3000, exchange, service.SubscriberOptions{})
+	t.Cleanup(srv.Stop)
+	if err := srv.Run(); err != nil {
+		t.Fatalf("Error running service: %v", err)
+	}
+	head, _ := srv.Head()
+	tail, _ := srv.Tail()
+	if srv.Size() < 3000 || tail.OpenTime-head.OpenTime != (srv.Size()-1)*1000 {
+		t.Errorf("Expected a contiguous window, got %d klines from %d to %d", srv.Size(), head.OpenTime, tail.OpenTime)
+	}
+}
+
+func TestKLineServiceBackfillsStreamGaps(t *testing.T) {
+	_, exchange := newTestExchange(t, Options{GapEvery: 2, DuplicateEvery: 3})
+	srv := service.NewKLineService("BTCUSDT", 1000, exchange, service.SubscriberOptions{})
+	t.Cleanup(srv.Stop)
+	if err := srv.Run(); err != nil {
+		t.Fatalf("Error running service: %v", err)
+	}
+	start, _ := srv.Tail()
+	// The stream skips every 2nd kline, wait until a few of them went by and were backfilled
+	deadline := time.Now().Add(20 * time.Second)
+	for {
+		head, _ := srv.Head()
+		tail, _ := srv.Tail()
+		contiguous := srv.Gaps() == 0 && tail.OpenTime-head.OpenTime == (srv.Size()-1)*1000
+		if contiguous && tail.OpenTime-start.OpenTime >= 5000 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected a contiguous window, got %d gaps and %d klines from %d to %d", srv.Gaps(), srv.Size(), head.OpenTime, tail.OpenTime)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
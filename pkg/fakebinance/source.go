@@ -0,0 +1,104 @@
+package fakebinance
+
+import (
+	"hash/fnv"
+	"math"
+	"os"
+
+	"github.com/BullionBear/crypto-feed/pkg/service"
+	"github.com/yosuke-furukawa/json5/encoding/json5"
+)
+
+type Source interface {
+	Kline(symbol string, openTime int64) (service.Kline, bool)
+}
+
+// generatedSource derives every kline from a hash of seed, symbol and open time,
+// so two servers with the same seed always agree on every bar.
+type generatedSource struct {
+	seed int64
+}
+
+func NewGeneratedSource(seed int64) Source {
+	return &generatedSource{
+		seed: seed,
+	}
+}
+
+func (src *generatedSource) Kline(symbol string, openTime int64) (service.Kline, bool) {
+	noise := func(salt int64) float64 {
+		h := fnv.New64a()
+		h.Write([]byte(symbol))
+		for _, v := range []int64{src.seed, openTime, salt} {
+			for i := 0; i < 8; i++ {
+				h.Write([]byte{byte(v >> (8 * i))})
+			}
+		}
+		return float64(h.Sum64()%1_000_000) / 1_000_000
+	}
+	h := fnv.New32a()
+	h.Write([]byte(symbol))
+	base := float64(100 + h.Sum32()%10_000)
+	trend := base * (1 + 0.05*math.Sin(float64(openTime)/3_600_000))
+	open := trend * (1 + (noise(0)-0.5)/1000)
+	close := trend * (1 + (noise(1)-0.5)/1000)
+	high := math.Max(open, close) * (1 + noise(2)/2000)
+	low := math.Min(open, close) * (1 - noise(3)/2000)
+	volume := math.Round(noise(4)*10_000) / 1000
+	takerBuyVolume := math.Round(volume*noise(5)*1000) / 1000
+	vwap := (open + close + high + low) / 4
+	return service.Kline{
+		OpenTime:                 openTime,
+		Open:                     round(open),
+		High:                     round(high),
+		Low:                      round(low),
+		Close:                    round(close),
+		Volume:                   volume,
+		CloseTime:                openTime + 999,
+		QuoteAssetVolume:         round(volume * vwap),
+		TradeNum:                 int64(noise(6) * 100),
+		TakerBuyBaseAssetVolume:  takerBuyVolume,
+		TakerBuyQuoteAssetVolume: round(takerBuyVolume * vwap),
+	}, true
+}
+
+// scriptedSource only serves the klines it was given, every other second is missing.
+type scriptedSource struct {
+	klines map[string]map[int64]service.Kline
+}
+
+func NewScriptedSource(script map[string][]service.Kline) Source {
+	klines := make(map[string]map[int64]service.Kline)
+	for symbol, symbolKlines := range script {
+		klines[symbol] = make(map[int64]service.Kline)
+		for _, kline := range symbolKlines {
+			klines[symbol][kline.OpenTime] = kline
+		}
+	}
+	return &scriptedSource{
+		klines: klines,
+	}
+}
+
+func (src *scriptedSource) Kline(symbol string, openTime int64) (service.Kline, bool) {
+	kline, exists := src.klines[symbol][openTime]
+	return kline, exists
+}
+
+// ReadScript loads a {"SYMBOL": [kline, ...]} file for NewScriptedSource.
+func ReadScript(path string) (map[string][]service.Kline, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var script map[string][]service.Kline
+	err = json5.Unmarshal(file, &script)
+	if err != nil {
+		return nil, err
+	}
+	return script, nil
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
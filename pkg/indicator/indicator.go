@@ -0,0 +1,341 @@
+package indicator
+
+/*
+Package indicator maintains technical indicators incrementally, one closed bar at a time.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/BullionBear/crypto-feed/pkg/service"
+)
+
+var errIndicatorNotExist = errors.New("indicator is not existed")
+
+const dayMs = 86_400_000
+
+// WarmupDay is the warm-up of indicators that need every bar since the start of the UTC day.
+const WarmupDay = -1
+
+// Indicator is updated with every closed bar in open time order.
+type Indicator interface {
+	Update(kline *service.Kline)
+	Values() []float64 // Latest values, meaningless until Ready
+	Ready() bool
+	Warmup() int // Bars to see before the values settle, or WarmupDay
+}
+
+// New builds an indicator by name, params left out take common defaults.
+func New(name string, params []float64) (Indicator, error) {
+	switch strings.ToLower(name) {
+	case "sma":
+		period, err := periodParam(params, 0, 20)
+		if err != nil {
+			return nil, err
+		}
+		return newSMA(period), nil
+	case "ema":
+		period, err := periodParam(params, 0, 20)
+		if err != nil {
+			return nil, err
+		}
+		return newEMA(period), nil
+	case "rsi":
+		period, err := periodParam(params, 0, 14)
+		if err != nil {
+			return nil, err
+		}
+		return newRSI(period), nil
+	case "vwap":
+		period, err := periodParam(params, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		return newVWAP(period), nil
+	case "atr":
+		period, err := periodParam(params, 0, 14)
+		if err != nil {
+			return nil, err
+		}
+		return newATR(period), nil
+	case "bbands":
+		period, err := periodParam(params, 0, 20)
+		if err != nil {
+			return nil, err
+		}
+		width := 2.0
+		if len(params) > 1 {
+			width = params[1]
+		}
+		if width <= 0 {
+			return nil, fmt.Errorf("bbands stddevs %v is not positive", width)
+		}
+		return newBollinger(period, width), nil
+	}
+	return nil, fmt.Errorf("%s: %w", name, errIndicatorNotExist)
+}
+
+// Key names an indicator and its params the way clients see it, e.g. bbands(20,2).
+func Key(name string, params []float64) string {
+	values := make([]string, len(params))
+	for i, param := range params {
+		values[i] = strconv.FormatFloat(param, 'f', -1, 64)
+	}
+	return strings.ToLower(name) + "(" + strings.Join(values, ",") + ")"
+}
+
+// periodParam reads params[i] as a bar count, fallback when it is left out.
+func periodParam(params []float64, i int, fallback int) (int, error) {
+	if i >= len(params) {
+		return fallback, nil
+	}
+	period := params[i]
+	if period < 1 || period != math.Trunc(period) || period > 100_000 {
+		return 0, fmt.Errorf("period %v is not a whole number of bars", period)
+	}
+	return int(period), nil
+}
+
+type window struct {
+	values []float64
+	next   int
+	full   bool
+	sum    float64
+}
+
+func newWindow(size int) *window {
+	return &window{values: make([]float64, size)}
+}
+
+func (w *window) push(value float64) {
+	w.sum += value - w.values[w.next]
+	w.values[w.next] = value
+	w.next = (w.next + 1) % len(w.values)
+	if w.next == 0 {
+		w.full = true
+		// Sum again once per turn so rounding errors do not pile up
+		w.sum = 0
+		for _, v := range w.values {
+			w.sum += v
+		}
+	}
+}
+
+func (w *window) len() int {
+	if w.full {
+		return len(w.values)
+	}
+	return w.next
+}
+
+func (w *window) mean() float64 {
+	if w.len() == 0 {
+		return 0
+	}
+	return w.sum / float64(w.len())
+}
+
+type sma struct {
+	closes *window
+}
+
+func newSMA(period int) *sma {
+	return &sma{closes: newWindow(period)}
+}
+
+func (ind *sma) Update(kline *service.Kline) { ind.closes.push(kline.Close) }
+func (ind *sma) Values() []float64           { return []float64{ind.closes.mean()} }
+func (ind *sma) Ready() bool                 { return ind.closes.full }
+func (ind *sma) Warmup() int                 { return len(ind.closes.values) }
+
+// ema is seeded with the SMA of its first period closes.
+type ema struct {
+	period int
+	seed   *window
+	value  float64
+}
+
+func newEMA(period int) *ema {
+	return &ema{period: period, seed: newWindow(period)}
+}
+
+func (ind *ema) Update(kline *service.Kline) {
+	if !ind.seed.full {
+		ind.seed.push(kline.Close)
+		ind.value = ind.seed.mean()
+		return
+	}
+	alpha := 2 / float64(ind.period+1)
+	ind.value += alpha * (kline.Close - ind.value)
+}
+
+func (ind *ema) Values() []float64 { return []float64{ind.value} }
+func (ind *ema) Ready() bool       { return ind.seed.full }
+func (ind *ema) Warmup() int       { return 4 * ind.period }
+
+// rsi uses Wilder's smoothing, seeded with the mean gain and loss of the first period changes.
+type rsi struct {
+	period    int
+	count     int // Changes seen
+	lastClose float64
+	avgGain   float64
+	avgLoss   float64
+}
+
+func newRSI(period int) *rsi {
+	return &rsi{period: period, count: -1}
+}
+
+func (ind *rsi) Update(kline *service.Kline) {
+	ind.count++
+	change := kline.Close - ind.lastClose
+	ind.lastClose = kline.Close
+	if ind.count == 0 {
+		return
+	}
+	gain, loss := math.Max(change, 0), math.Max(-change, 0)
+	n := float64(ind.period)
+	if ind.count <= ind.period {
+		ind.avgGain += gain / n
+		ind.avgLoss += loss / n
+		return
+	}
+	ind.avgGain = (ind.avgGain*(n-1) + gain) / n
+	ind.avgLoss = (ind.avgLoss*(n-1) + loss) / n
+}
+
+func (ind *rsi) Values() []float64 {
+	if ind.avgLoss == 0 {
+		if ind.avgGain == 0 {
+			return []float64{50}
+		}
+		return []float64{100}
+	}
+	return []float64{100 - 100/(1+ind.avgGain/ind.avgLoss)}
+}
+
+func (ind *rsi) Ready() bool { return ind.count >= ind.period }
+func (ind *rsi) Warmup() int { return 4*ind.period + 1 }
+
+// vwap weights the typical price by volume over the last period bars, or since the start
+// of the UTC day when period is 0.
+type vwap struct {
+	period    int
+	prices    *window // Typical price × volume
+	volumes   *window
+	day       int64
+	priceSum  float64
+	volumeSum float64
+	lastClose float64
+	count     int
+}
+
+func newVWAP(period int) *vwap {
+	ind := &vwap{period: period}
+	if period > 0 {
+		ind.prices = newWindow(period)
+		ind.volumes = newWindow(period)
+	}
+	return ind
+}
+
+func (ind *vwap) Update(kline *service.Kline) {
+	ind.count++
+	ind.lastClose = kline.Close
+	typical := (kline.High + kline.Low + kline.Close) / 3
+	if ind.period > 0 {
+		ind.prices.push(typical * kline.Volume)
+		ind.volumes.push(kline.Volume)
+		return
+	}
+	if day := kline.OpenTime - kline.OpenTime%dayMs; day != ind.day {
+		ind.day, ind.priceSum, ind.volumeSum = day, 0, 0
+	}
+	ind.priceSum += typical * kline.Volume
+	ind.volumeSum += kline.Volume
+}
+
+func (ind *vwap) Values() []float64 {
+	priceSum, volumeSum := ind.priceSum, ind.volumeSum
+	if ind.period > 0 {
+		priceSum, volumeSum = ind.prices.sum, ind.volumes.sum
+	}
+	if volumeSum == 0 {
+		return []float64{ind.lastClose}
+	}
+	return []float64{priceSum / volumeSum}
+}
+
+func (ind *vwap) Ready() bool {
+	if ind.period > 0 {
+		return ind.prices.full
+	}
+	return ind.count > 0
+}
+
+func (ind *vwap) Warmup() int {
+	if ind.period > 0 {
+		return ind.period
+	}
+	return WarmupDay
+}
+
+// atr uses Wilder's smoothing of the true range, seeded with the mean of the first period ranges.
+type atr struct {
+	period    int
+	count     int
+	lastClose float64
+	value     float64
+}
+
+func newATR(period int) *atr {
+	return &atr{period: period}
+}
+
+func (ind *atr) Update(kline *service.Kline) {
+	trueRange := kline.High - kline.Low
+	if ind.count > 0 {
+		trueRange = math.Max(trueRange, math.Max(math.Abs(kline.High-ind.lastClose), math.Abs(kline.Low-ind.lastClose)))
+	}
+	ind.count++
+	ind.lastClose = kline.Close
+	n := float64(ind.period)
+	if ind.count <= ind.period {
+		ind.value += (trueRange - ind.value) / float64(ind.count)
+		return
+	}
+	ind.value = (ind.value*(n-1) + trueRange) / n
+}
+
+func (ind *atr) Values() []float64 { return []float64{ind.value} }
+func (ind *atr) Ready() bool       { return ind.count >= ind.period }
+func (ind *atr) Warmup() int       { return 4*ind.period + 1 }
+
+// bollinger is the SMA of the closes and bands width population standard deviations around it.
+type bollinger struct {
+	closes  *window
+	squares *window
+	width   float64
+}
+
+func newBollinger(period int, width float64) *bollinger {
+	return &bollinger{closes: newWindow(period), squares: newWindow(period), width: width}
+}
+
+func (ind *bollinger) Update(kline *service.Kline) {
+	ind.closes.push(kline.Close)
+	ind.squares.push(kline.Close * kline.Close)
+}
+
+func (ind *bollinger) Values() []float64 {
+	mean := ind.closes.mean()
+	deviation := math.Sqrt(math.Max(ind.squares.mean()-mean*mean, 0))
+	return []float64{mean, mean + ind.width*deviation, mean - ind.width*deviation}
+}
+
+func (ind *bollinger) Ready() bool { return ind.closes.full }
+func (ind *bollinger) Warmup() int { return len(ind.closes.values) }
@@ -0,0 +1,113 @@
+package indicator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/BullionBear/crypto-feed/pkg/service"
+)
+
+func feed(ind Indicator, klines []service.Kline) {
+	for i := range klines {
+		ind.Update(&klines[i])
+	}
+}
+
+func closes(values ...float64) []service.Kline {
+	klines := make([]service.Kline, len(values))
+	for i, value := range values {
+		klines[i] = service.Kline{OpenTime: int64(i) * 1000, Close: value, High: value, Low: value, Volume: 1}
+	}
+	return klines
+}
+
+func expectValues(t *testing.T, name string, ind Indicator, expected ...float64) {
+	t.Helper()
+	if !ind.Ready() {
+		t.Fatalf("Expected %s to be ready", name)
+	}
+	values := ind.Values()
+	if len(values) != len(expected) {
+		t.Fatalf("Expected %s to be %v, got %v", name, expected, values)
+	}
+	for i := range expected {
+		if math.Abs(values[i]-expected[i]) > 1e-9 {
+			t.Errorf("Expected %s to be %v, got %v", name, expected, values)
+			return
+		}
+	}
+}
+
+func TestMovingAverages(t *testing.T) {
+	sma, _ := New("sma", []float64{3})
+	feed(sma, closes(1, 2))
+	if sma.Ready() {
+		t.Errorf("Expected sma(3) not to be ready after 2 bars")
+	}
+	feed(sma, closes(3, 4, 5))
+	expectValues(t, "sma(3)", sma, 4)
+
+	// Seeded with the SMA of 1, 2, 3, then alpha is 0.5
+	ema, _ := New("ema", []float64{3})
+	feed(ema, closes(1, 2, 3, 4, 5))
+	expectValues(t, "ema(3)", ema, 4)
+
+	// Mean 2, population stddev sqrt(2/3)
+	bbands, _ := New("bbands", []float64{3, 2})
+	feed(bbands, closes(1, 2, 3))
+	deviation := math.Sqrt(2.0 / 3)
+	expectValues(t, "bbands(3,2)", bbands, 2, 2+2*deviation, 2-2*deviation)
+}
+
+func TestWilderIndicators(t *testing.T) {
+	// Changes +1, +1 seed gain 1 and loss 0, then -1 smooths both to 0.5
+	rsi, _ := New("rsi", []float64{2})
+	feed(rsi, closes(1, 2, 3))
+	expectValues(t, "rsi(2)", rsi, 100)
+	feed(rsi, closes(2))
+	expectValues(t, "rsi(2)", rsi, 50)
+
+	// True ranges 1, 1.5 seed 1.25, then 2 smooths to 1.625
+	atr, _ := New("atr", []float64{2})
+	feed(atr, []service.Kline{
+		{High: 2, Low: 1, Close: 1.5},
+		{High: 3, Low: 2, Close: 2.5},
+		{High: 4, Low: 2, Close: 3},
+	})
+	expectValues(t, "atr(2)", atr, 1.625)
+}
+
+func TestVWAP(t *testing.T) {
+	klines := []service.Kline{
+		{OpenTime: dayMs - 1000, High: 30, Low: 30, Close: 30, Volume: 5},
+		{OpenTime: dayMs, High: 12, Low: 9, Close: 9, Volume: 1},
+		{OpenTime: dayMs + 1000, High: 20, Low: 20, Close: 20, Volume: 3},
+	}
+	daily, _ := New("vwap", nil)
+	if daily.Warmup() != WarmupDay {
+		t.Errorf("Expected the daily vwap to warm up from the start of the day")
+	}
+	feed(daily, klines)
+	expectValues(t, "vwap()", daily, (10*1+20*3)/4.0)
+
+	rolling, _ := New("vwap", []float64{2})
+	feed(rolling, klines[:2])
+	expectValues(t, "vwap(2)", rolling, (30*5+10*1)/6.0)
+}
+
+func TestNew(t *testing.T) {
+	for _, params := range [][]float64{{0}, {2.5}, {-1}} {
+		if _, err := New("sma", params); err == nil {
+			t.Errorf("Expected period %v to be rejected", params)
+		}
+	}
+	if _, err := New("macd", nil); err == nil {
+		t.Errorf("Expected an unknown indicator to be rejected")
+	}
+	if _, err := New("bbands", []float64{20, 0}); err == nil {
+		t.Errorf("Expected zero stddevs to be rejected")
+	}
+	if key := Key("BBands", []float64{20, 2.5}); key != "bbands(20,2.5)" {
+		t.Errorf("Unexpected key %s", key)
+	}
+}
@@ -132,6 +132,35 @@ func (ls *IndexLinkedList[T]) PushFront(index int64, data T) error {
 	return nil
 }
 
+// InsertAfter links a new node right after the node at prevIndex.
+func (ls *IndexLinkedList[T]) InsertAfter(prevIndex int64, index int64, data T) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	prev, exists := ls.nodeIndex[prevIndex]
+	if !exists {
+		return errIndexNotExist
+	}
+	if _, exists := ls.nodeIndex[index]; exists {
+		return errIndexExist
+	}
+
+	newNode := &IndexedNode[T]{
+		data:  data,
+		index: index,
+		prev:  prev,
+		next:  prev.next,
+	}
+	if prev.next != nil {
+		prev.next.prev = newNode
+	} else {
+		ls.tail = newNode
+	}
+	prev.next = newNode
+	ls.nodeIndex[index] = newNode
+	ls.size++
+	return nil
+}
+
 func (ls *IndexLinkedList[T]) PopBack() (T, error) {
 	ls.mu.Lock()
 	defer ls.mu.Unlock()
@@ -215,6 +244,18 @@ func (ls *IndexLinkedList[T]) Get(index int64) (T, error) {
 	return zero, errIndexNotExist
 }
 
+// Update replaces the data of an existing node in place.
+func (ls *IndexLinkedList[T]) Update(index int64, data T) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	node, exists := ls.nodeIndex[index]
+	if !exists {
+		return errIndexNotExist
+	}
+	node.data = data
+	return nil
+}
+
 func (ls *IndexLinkedList[T]) Size() int64 {
 	ls.mu.RLock()
 	defer ls.mu.RUnlock()
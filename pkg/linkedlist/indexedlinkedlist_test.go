@@ -172,3 +172,57 @@ func TestSize(t *testing.T) {
 		t.Errorf("Expected size 1 after one push, got %d", ll.Size())
 	}
 }
+
+func TestInsertAfter(t *testing.T) {
+	ll := NewIndexedLinkedList[int]()
+	err := ll.InsertAfter(1, 2, 200)
+	if err != errIndexNotExist {
+		t.Errorf("Expected error 'index is not existed' for InsertAfter on non-existent index, got %v", err)
+	}
+
+	ll.PushBack(1, 100)
+	ll.PushBack(3, 300)
+	if err := ll.InsertAfter(1, 2, 200); err != nil {
+		t.Errorf("Unexpected error on InsertAfter: %v", err)
+	}
+	if err := ll.InsertAfter(1, 3, 300); err != errIndexExist {
+		t.Errorf("Expected error 'index is existed' for duplicate index, got %v", err)
+	}
+	if next, _ := ll.Next(1); next != 2 {
+		t.Errorf("Expected next of 1 to be 2, got %d", next)
+	}
+	if prev, _ := ll.Prev(3); prev != 2 {
+		t.Errorf("Expected prev of 3 to be 2, got %d", prev)
+	}
+
+	if err := ll.InsertAfter(3, 4, 400); err != nil {
+		t.Errorf("Unexpected error on InsertAfter at tail: %v", err)
+	}
+	tail, _ := ll.Tail()
+	if tail != 400 {
+		t.Errorf("Expected tail data of 400, got %d", tail)
+	}
+	if ll.Size() != 4 {
+		t.Errorf("Expected size 4 after inserts, got %d", ll.Size())
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ll := NewIndexedLinkedList[int]()
+	err := ll.Update(1, 100)
+	if err != errIndexNotExist {
+		t.Errorf("Expected error 'index is not existed' for Update on non-existent index, got %v", err)
+	}
+
+	ll.PushBack(1, 100)
+	if err := ll.Update(1, 101); err != nil {
+		t.Errorf("Unexpected error on Update: %v", err)
+	}
+	data, _ := ll.Get(1)
+	if data != 101 {
+		t.Errorf("Expected to get updated data 101, got %d", data)
+	}
+	if ll.Size() != 1 {
+		t.Errorf("Expected size 1 after update, got %d", ll.Size())
+	}
+}
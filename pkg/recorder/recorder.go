@@ -0,0 +1,240 @@
+package recorder
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+	"github.com/BullionBear/crypto-feed/pkg/service"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultBatchSize     = 500
+	defaultBufferSize    = 1_000_000
+	defaultFlushInterval = time.Second
+	defaultMaxBackoff    = time.Minute
+	resubscribeDelay     = time.Second
+)
+
+// Store is where the recorder upserts klines, implemented by pgdb.PgDatabase.
+type Store interface {
+	UpsertKlines(symbol, interval string, klines []pgdb.PlaybackKline) error
+}
+
+type Options struct {
+	BatchSize     int           // Rows per upsert
+	BufferSize    int           // Klines kept in memory while the store is unavailable
+	FlushInterval time.Duration // Time between flushes
+	MaxBackoff    time.Duration // Upper bound of the retry delay after a failed flush
+}
+
+// Recorder buffers the final klines of a KLineService and upserts them into a Store in batches.
+type Recorder struct {
+	store  Store
+	symbol string
+	opts   Options
+
+	mutex        sync.Mutex
+	buffer       []pgdb.PlaybackKline
+	dropped      int64
+	lastOpenTime int64 // Last kline added, a resumed subscription replays from the one after it
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+func NewRecorder(store Store, symbol string, opts Options) *Recorder {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultBufferSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultFlushInterval
+	}
+	if opts.MaxBackoff < opts.FlushInterval {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+	return &Recorder{
+		store:  store,
+		symbol: symbol,
+		opts:   opts,
+		buffer: make([]pgdb.PlaybackKline, 0, opts.BatchSize),
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Attach records the closed klines of srv until Stop. The subscription disconnects rather than drop
+// a kline, and whenever it is dropped, on overflow or by an admin, it resumes after the last kline added.
+func (r *Recorder) Attach(srv *service.KLineService) {
+	id := srv.SubscribeStateful(r.Add)
+	go r.follow(srv, id)
+}
+
+func (r *Recorder) follow(srv *service.KLineService, id int64) {
+	for {
+		srv.DescribeSubscriber(id, service.SubscriberMeta{Name: "recorder"})
+		kickedCh, err := srv.Kicked(id)
+		if err != nil {
+			log.Errorf("%s recorder fail to watch subscriber %d: %s", r.symbol, id, err.Error())
+			return
+		}
+		select {
+		case err := <-kickedCh:
+			log.Warnf("%s recorder subscriber %d is dropped, resume after %d: %s", r.symbol, id, r.LastOpenTime(), err.Error())
+			srv.Unsubscribe(id)
+		case <-r.stopCh:
+			srv.Unsubscribe(id)
+			return
+		}
+		if id, err = r.resubscribe(srv); err != nil {
+			return
+		}
+	}
+}
+
+// resubscribe replays the klines after the last one added, retrying until the service has klines.
+func (r *Recorder) resubscribe(srv *service.KLineService) (int64, error) {
+	for {
+		from := r.LastOpenTime() + 1000
+		if from == 1000 {
+			from = 0 // Nothing recorded yet, take whatever the window holds
+		}
+		id, err := srv.SubscribeFrom(from, "1s", r.Add)
+		if errors.Is(err, service.ErrResumeTooOld) {
+			if from != 0 {
+				log.Errorf("%s recorder lost the klines from %d, they left the window: %s", r.symbol, from, err.Error())
+			}
+			id, err = srv.SubscribeSince(from, "1s", r.Add)
+		}
+		if err == nil {
+			return id, nil
+		}
+		log.Errorf("%s recorder fail to resubscribe: %s", r.symbol, err.Error())
+		select {
+		case <-time.After(resubscribeDelay):
+		case <-r.stopCh:
+			return 0, err
+		}
+	}
+}
+
+// Stop ends Run and the subscription of Attach, the buffer is kept for a last Flush.
+func (r *Recorder) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+	})
+}
+
+// Add buffers a final kline, dropping the oldest one when the buffer is full. Klines not after
+// the last one added are replays of a resumed subscription and skipped.
+func (r *Recorder) Add(kline *service.Kline) {
+	if !kline.IsFinal {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.lastOpenTime != 0 && kline.OpenTime <= r.lastOpenTime {
+		return
+	}
+	r.lastOpenTime = kline.OpenTime
+	if len(r.buffer) >= r.opts.BufferSize {
+		r.buffer = r.buffer[1:]
+		r.dropped++
+		if r.dropped%10_000 == 1 {
+			log.Warnf("%s recorder buffer is full, %d klines dropped", r.symbol, r.dropped)
+		}
+	}
+	r.buffer = append(r.buffer, toPlaybackKline(kline))
+}
+
+func (r *Recorder) Pending() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.buffer)
+}
+
+func (r *Recorder) Dropped() int64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.dropped
+}
+
+func (r *Recorder) LastOpenTime() int64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.lastOpenTime
+}
+
+// Run flushes the buffer until Stop, backing off while the store fails.
+func (r *Recorder) Run() {
+	delay := r.opts.FlushInterval
+	for {
+		select {
+		case <-time.After(delay):
+		case <-r.stopCh:
+			return
+		}
+		if err := r.Flush(); err != nil {
+			delay *= 2
+			if delay > r.opts.MaxBackoff {
+				delay = r.opts.MaxBackoff
+			}
+			log.Errorf("%s recorder fail to write, retry in %s: %s", r.symbol, delay, err.Error())
+			continue
+		}
+		delay = r.opts.FlushInterval
+	}
+}
+
+// Flush writes the buffer in batches, keeping whatever failed to be written.
+func (r *Recorder) Flush() error {
+	for {
+		r.mutex.Lock()
+		n := len(r.buffer)
+		if n > r.opts.BatchSize {
+			n = r.opts.BatchSize
+		}
+		batch := make([]pgdb.PlaybackKline, n)
+		copy(batch, r.buffer)
+		r.mutex.Unlock()
+		if n == 0 {
+			return nil
+		}
+		if err := r.store.UpsertKlines(r.symbol, "1s", batch); err != nil {
+			return err
+		}
+		r.mutex.Lock()
+		r.remove(batch)
+		r.mutex.Unlock()
+	}
+}
+
+// remove drops the written batch from the head of the buffer, unless the buffer overflowed meanwhile.
+func (r *Recorder) remove(batch []pgdb.PlaybackKline) {
+	last := batch[len(batch)-1].OpenTime
+	i := 0
+	for i < len(r.buffer) && r.buffer[i].OpenTime <= last {
+		i++
+	}
+	r.buffer = r.buffer[i:]
+}
+
+func toPlaybackKline(kline *service.Kline) pgdb.PlaybackKline {
+	return pgdb.PlaybackKline{
+		OpenTime:            kline.OpenTime,
+		Open:                kline.Open,
+		High:                kline.High,
+		Low:                 kline.Low,
+		Close:               kline.Close,
+		Volume:              kline.Volume,
+		CloseTime:           kline.CloseTime,
+		QuoteVolume:         kline.QuoteAssetVolume,
+		Count:               kline.TradeNum,
+		TakerBuyVolume:      kline.TakerBuyBaseAssetVolume,
+		TakerBuyQuoteVolume: kline.TakerBuyQuoteAssetVolume,
+	}
+}
@@ -0,0 +1,204 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BullionBear/crypto-feed/domain/pgdb"
+	"github.com/BullionBear/crypto-feed/pkg/service"
+)
+
+// fakeStore keeps rows by open time, so repeated writes behave like an upsert.
+type fakeStore struct {
+	fail    bool
+	batches int
+	rows    map[int64]pgdb.PlaybackKline
+}
+
+func (store *fakeStore) UpsertKlines(symbol, interval string, klines []pgdb.PlaybackKline) error {
+	if symbol != "BTCUSDT" || interval != "1s" {
+		return errors.New("unexpected table of " + symbol + " " + interval)
+	}
+	if store.fail {
+		return errors.New("database is unavailable")
+	}
+	store.batches++
+	for _, kline := range klines {
+		store.rows[kline.OpenTime] = kline
+	}
+	return nil
+}
+
+func newKline(openTime int64, isFinal bool) *service.Kline {
+	return &service.Kline{OpenTime: openTime, Close: float64(openTime), CloseTime: openTime + 999, IsFinal: isFinal}
+}
+
+func TestRecorderRetriesFailedBatches(t *testing.T) {
+	store := &fakeStore{fail: true, rows: map[int64]pgdb.PlaybackKline{}}
+	rec := NewRecorder(store, "BTCUSDT", Options{BatchSize: 2})
+	for i := int64(0); i < 5; i++ {
+		rec.Add(newKline(i*1000, true))
+	}
+	rec.Add(newKline(5000, false))
+	if err := rec.Flush(); err == nil {
+		t.Fatalf("Expected flush to fail")
+	}
+	if rec.Pending() != 5 {
+		t.Fatalf("Expected 5 pending klines, got %d", rec.Pending())
+	}
+	store.fail = false
+	if err := rec.Flush(); err != nil {
+		t.Fatalf("Error flushing: %v", err)
+	}
+	if rec.Pending() != 0 || len(store.rows) != 5 || store.batches != 3 {
+		t.Errorf("Expected 5 rows in 3 batches, got %d rows in %d batches, %d pending", len(store.rows), store.batches, rec.Pending())
+	}
+}
+
+func TestRecorderBoundedBuffer(t *testing.T) {
+	store := &fakeStore{rows: map[int64]pgdb.PlaybackKline{}}
+	rec := NewRecorder(store, "BTCUSDT", Options{BufferSize: 3})
+	for i := int64(0); i < 5; i++ {
+		rec.Add(newKline(i*1000, true))
+	}
+	if rec.Pending() != 3 || rec.Dropped() != 2 {
+		t.Fatalf("Expected 3 pending and 2 dropped, got %d and %d", rec.Pending(), rec.Dropped())
+	}
+	rec.Flush()
+	if _, ok := store.rows[0]; ok {
+		t.Errorf("Expected the oldest kline to be dropped")
+	}
+	if _, ok := store.rows[4000]; !ok {
+		t.Errorf("Expected the newest kline to be written")
+	}
+}
+
+const fakeNow = int64(1_700_000_000_000)
+
+// fakeExchange serves final 1s klines up to fakeNow, and the klines the test streams after it.
+type fakeExchange struct {
+	mutex   sync.Mutex
+	handler func(*service.Kline)
+}
+
+func (ex *fakeExchange) KlinePage(ctx context.Context, symbol, interval string, startTime, endTime int64, limit int) ([]*service.Kline, error) {
+	klines := []*service.Kline{}
+	for openTime := (startTime + 999) / 1000 * 1000; openTime <= endTime && openTime <= fakeNow && len(klines) < limit; openTime += 1000 {
+		klines = append(klines, newKline(openTime, true))
+	}
+	return klines, nil
+}
+
+func (ex *fakeExchange) RecentKlines(ctx context.Context, symbol, interval string, limit int) ([]*service.Kline, error) {
+	return ex.KlinePage(ctx, symbol, interval, fakeNow-int64(limit-1)*1000, fakeNow, limit)
+}
+
+func (ex *fakeExchange) KlineStream(symbol, interval string, handler func(*service.Kline), errHandler func(error)) (doneC, stopC chan struct{}, err error) {
+	ex.mutex.Lock()
+	ex.handler = handler
+	ex.mutex.Unlock()
+	doneC, stopC = make(chan struct{}), make(chan struct{})
+	go func() {
+		<-stopC
+		close(doneC)
+	}()
+	return doneC, stopC, nil
+}
+
+func (ex *fakeExchange) stream(kline *service.Kline) bool {
+	ex.mutex.Lock()
+	defer ex.mutex.Unlock()
+	if ex.handler == nil {
+		return false
+	}
+	ex.handler(kline)
+	return true
+}
+
+// waitRecorded polls until the recorder added the kline opened at openTime and every one before it since from.
+func waitRecorded(t *testing.T, rec *Recorder, from, openTime int64) {
+	deadline := time.Now().Add(10 * time.Second)
+	for rec.LastOpenTime() < openTime {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected klines up to %d, recorded up to %d", openTime, rec.LastOpenTime())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	expected := from
+	for _, kline := range rec.buffer {
+		if kline.OpenTime < from {
+			continue
+		}
+		if kline.OpenTime != expected {
+			t.Fatalf("Expected kline %d, got %d", expected, kline.OpenTime)
+		}
+		expected += 1000
+	}
+	if expected != openTime+1000 {
+		t.Fatalf("Expected klines up to %d, got up to %d", openTime, expected-1000)
+	}
+}
+
+func TestRecorderQueueOverflow(t *testing.T) {
+	// The 5 latest klines are published at startup, a queue of 2 under drop_oldest would lose some
+	srv := service.NewKLineService("BTCUSDT", 100, &fakeExchange{}, service.SubscriberOptions{QueueSize: 2, Overflow: service.OverflowDropOldest})
+	t.Cleanup(srv.Stop)
+	rec := NewRecorder(&fakeStore{rows: map[int64]pgdb.PlaybackKline{}}, "BTCUSDT", Options{})
+	t.Cleanup(rec.Stop)
+	rec.mutex.Lock() // Hold the handler so the queue overflows
+	rec.Attach(srv)
+	if err := srv.Run(); err != nil {
+		t.Fatalf("Error running service: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	rec.mutex.Unlock()
+	waitRecorded(t, rec, fakeNow-4000, fakeNow)
+	if infos := srv.SubscriberInfos(); len(infos) != 1 || infos[0].ID == 0 {
+		t.Errorf("Expected the recorder to be kicked and resubscribed, got %+v", infos)
+	}
+}
+
+func TestRecorderKicked(t *testing.T) {
+	ex := &fakeExchange{}
+	srv := service.NewKLineService("BTCUSDT", 100, ex, service.SubscriberOptions{})
+	t.Cleanup(srv.Stop)
+	rec := NewRecorder(&fakeStore{rows: map[int64]pgdb.PlaybackKline{}}, "BTCUSDT", Options{})
+	t.Cleanup(rec.Stop)
+	rec.Attach(srv)
+	if err := srv.Run(); err != nil {
+		t.Fatalf("Error running service: %v", err)
+	}
+	waitRecorded(t, rec, fakeNow, fakeNow)
+
+	infos := srv.SubscriberInfos()
+	if len(infos) != 1 || infos[0].Name != "recorder" {
+		t.Fatalf("Expected the recorder subscriber, got %+v", infos)
+	}
+	rec.mutex.Lock() // Klines streamed while the recorder is away are replayed on resume
+	if err := srv.Disconnect(infos[0].ID); err != nil {
+		t.Fatalf("Error disconnecting: %v", err)
+	}
+	for !ex.stream(newKline(fakeNow+1000, true)) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	ex.stream(newKline(fakeNow+2000, true))
+	ex.stream(newKline(fakeNow+3000, false)) // Closes fakeNow+2000
+	rec.mutex.Unlock()
+	waitRecorded(t, rec, fakeNow, fakeNow+2000)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		infos = srv.SubscriberInfos()
+		if len(infos) == 1 && infos[0].Name == "recorder" && infos[0].ID != 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the recorder to resubscribe, got %+v", infos)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"errors"
+	"math"
+)
+
+var errIntervalNotSupport = errors.New("interval is not supported")
+
+const baseInterval = "1s"
+
+var intervalMs = map[string]int64{
+	"1s":  1_000,
+	"1m":  60_000,
+	"5m":  300_000,
+	"15m": 900_000,
+	"1h":  3_600_000,
+	"1d":  86_400_000,
+}
+
+// IntervalMs returns the width of an interval in milliseconds, an empty interval means 1s.
+func IntervalMs(interval string) (int64, error) {
+	if interval == "" {
+		interval = baseInterval
+	}
+	width, exists := intervalMs[interval]
+	if !exists {
+		return 0, errIntervalNotSupport
+	}
+	return width, nil
+}
+
+// KlineAggregator rolls 1s klines up into bars of a wider interval.
+type KlineAggregator struct {
+	width   int64
+	current *Kline
+}
+
+func NewKlineAggregator(interval string) (*KlineAggregator, error) {
+	width, err := IntervalMs(interval)
+	if err != nil {
+		return nil, err
+	}
+	return &KlineAggregator{
+		width: width,
+	}, nil
+}
+
+func (agg *KlineAggregator) bucketStart(openTime int64) int64 {
+	return openTime - openTime%agg.width
+}
+
+func (agg *KlineAggregator) isEmpty() bool {
+	return agg.current == nil
+}
+
+// Add merges a 1s kline into the current bucket and returns the finished bars,
+// a bar is finished once its last second arrives or a kline of a later bucket shows up.
+func (agg *KlineAggregator) Add(kline *Kline) []*Kline {
+	finished := []*Kline{}
+	start := agg.bucketStart(kline.OpenTime)
+	if agg.current != nil && agg.current.OpenTime != start {
+		agg.current.IsFinal = true
+		finished = append(finished, agg.current)
+		agg.current = nil
+	}
+	if agg.current == nil {
+		agg.current = &Kline{
+			OpenTime:  start,
+			Open:      kline.Open,
+			High:      math.Inf(-1),
+			Low:       math.Inf(1),
+			CloseTime: start + agg.width - 1,
+		}
+	}
+	bar := agg.current
+	mergeKline(bar, kline)
+	if kline.CloseTime >= bar.CloseTime {
+		bar.IsFinal = true
+		finished = append(finished, bar)
+		agg.current = nil
+	}
+	return finished
+}
+
+// Flush returns the bar of the current bucket before its last second arrived, nil if there is none.
+// The bar is not final, it is flushed because the klines read end mid-bucket.
+func (agg *KlineAggregator) Flush() *Kline {
+	bar := agg.current
+	agg.current = nil
+	return bar
+}
+
+func mergeKline(bar *Kline, kline *Kline) {
+	bar.High = math.Max(bar.High, kline.High)
+	bar.Low = math.Min(bar.Low, kline.Low)
+	bar.Close = kline.Close
+	bar.Volume += kline.Volume
+	bar.QuoteAssetVolume += kline.QuoteAssetVolume
+	bar.TradeNum += kline.TradeNum
+	bar.TakerBuyBaseAssetVolume += kline.TakerBuyBaseAssetVolume
+	bar.TakerBuyQuoteAssetVolume += kline.TakerBuyQuoteAssetVolume
+}
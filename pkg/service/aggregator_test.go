@@ -0,0 +1,69 @@
+package service
+
+import "testing"
+
+func newSecondKline(openTime int64, price float64) *Kline {
+	return &Kline{
+		OpenTime:                 openTime,
+		Open:                     price,
+		High:                     price + 1,
+		Low:                      price - 1,
+		Close:                    price,
+		Volume:                   1,
+		CloseTime:                openTime + 999,
+		QuoteAssetVolume:         price,
+		TradeNum:                 2,
+		TakerBuyBaseAssetVolume:  0.5,
+		TakerBuyQuoteAssetVolume: price / 2,
+		IsFinal:                  true,
+	}
+}
+
+func TestIntervalMs(t *testing.T) {
+	width, err := IntervalMs("")
+	if err != nil || width != 1000 {
+		t.Errorf("Expected empty interval to be 1000ms, got %d, %v", width, err)
+	}
+	if _, err := IntervalMs("3m"); err != errIntervalNotSupport {
+		t.Errorf("Expected errIntervalNotSupport, got %v", err)
+	}
+}
+
+func TestAggregatorEmitsOnLastSecond(t *testing.T) {
+	agg, _ := NewKlineAggregator("1m")
+	var bars []*Kline
+	for i := int64(0); i < 60; i++ {
+		bars = append(bars, agg.Add(newSecondKline(60_000+i*1000, float64(100+i)))...)
+	}
+	if len(bars) != 1 {
+		t.Fatalf("Expected 1 bar, got %d", len(bars))
+	}
+	bar := bars[0]
+	if bar.OpenTime != 60_000 || bar.CloseTime != 119_999 {
+		t.Errorf("Unexpected bar times %d - %d", bar.OpenTime, bar.CloseTime)
+	}
+	if bar.Open != 100 || bar.Close != 159 || bar.High != 160 || bar.Low != 99 {
+		t.Errorf("Unexpected OHLC %v %v %v %v", bar.Open, bar.High, bar.Low, bar.Close)
+	}
+	if bar.Volume != 60 || bar.TradeNum != 120 || bar.TakerBuyBaseAssetVolume != 30 {
+		t.Errorf("Unexpected volume %v trades %d taker %v", bar.Volume, bar.TradeNum, bar.TakerBuyBaseAssetVolume)
+	}
+	if !bar.IsFinal {
+		t.Errorf("Expected emitted bar to be final")
+	}
+	if !agg.isEmpty() {
+		t.Errorf("Expected aggregator to be empty after emitting")
+	}
+}
+
+func TestAggregatorFlushesOnNextBucket(t *testing.T) {
+	agg, _ := NewKlineAggregator("1m")
+	agg.Add(newSecondKline(60_000, 100))
+	bars := agg.Add(newSecondKline(125_000, 101))
+	if len(bars) != 1 || bars[0].OpenTime != 60_000 || bars[0].Close != 100 {
+		t.Errorf("Expected the previous bucket to be flushed, got %+v", bars)
+	}
+	if agg.isEmpty() {
+		t.Errorf("Expected the new bucket to be open")
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"math"
+)
+
+var errThresholdNotPositive = errors.New("bar threshold is not positive")
+var errBarTypeNotSupport = errors.New("bar type is not supported")
+
+type BarType int
+
+const (
+	TimeBar       BarType = iota // Bars of a fixed interval
+	VolumeBar                    // Closed once the base asset volume reaches a threshold
+	DollarBar                    // Closed once the quote asset volume reaches a threshold
+	TickBar                      // Closed once the trade count reaches a threshold
+	HeikinAshiBar                // Heikin-Ashi candles of a fixed interval
+)
+
+// heikinAshiWarmup is the bars fed to a Heikin-Ashi builder before the first bar is passed on,
+// the open halves its distance to the settled value with every bar.
+const heikinAshiWarmup = 30
+
+// ThresholdBars sums a measure of 1s klines and closes a bar once the sum reaches the threshold,
+// a bar always ends on a whole 1s kline so it can overshoot.
+type ThresholdBars struct {
+	measure   func(kline *Kline) float64
+	threshold float64
+	sum       float64
+	current   *Kline
+}
+
+func NewThresholdBars(barType BarType, threshold float64) (*ThresholdBars, error) {
+	if !(threshold > 0) || math.IsInf(threshold, 1) {
+		return nil, errThresholdNotPositive
+	}
+	bars := &ThresholdBars{threshold: threshold}
+	switch barType {
+	case VolumeBar:
+		bars.measure = func(kline *Kline) float64 { return kline.Volume }
+	case DollarBar:
+		bars.measure = func(kline *Kline) float64 { return kline.QuoteAssetVolume }
+	case TickBar:
+		bars.measure = func(kline *Kline) float64 { return float64(kline.TradeNum) }
+	default:
+		return nil, errBarTypeNotSupport
+	}
+	return bars, nil
+}
+
+// Add merges a closed 1s kline into the current bar and returns it once it reaches the threshold.
+func (bars *ThresholdBars) Add(kline *Kline) []*Kline {
+	if bars.current == nil {
+		bars.current = &Kline{
+			OpenTime: kline.OpenTime,
+			Open:     kline.Open,
+			High:     math.Inf(-1),
+			Low:      math.Inf(1),
+		}
+	}
+	bar := bars.current
+	mergeKline(bar, kline)
+	bar.CloseTime = kline.CloseTime
+	bars.sum += bars.measure(kline)
+	if bars.sum < bars.threshold {
+		return nil
+	}
+	bar.IsFinal = true
+	bars.current, bars.sum = nil, 0
+	return []*Kline{bar}
+}
+
+// HeikinAshi turns time bars into Heikin-Ashi candles, each depending on the candle before it.
+type HeikinAshi struct {
+	previous *Kline
+}
+
+func (ha *HeikinAshi) Add(bar *Kline) *Kline {
+	candle := *bar
+	candle.Close = (bar.Open + bar.High + bar.Low + bar.Close) / 4
+	candle.Open = (bar.Open + bar.Close) / 2
+	if ha.previous != nil {
+		candle.Open = (ha.previous.Open + ha.previous.Close) / 2
+	}
+	candle.High = math.Max(bar.High, math.Max(candle.Open, candle.Close))
+	candle.Low = math.Min(bar.Low, math.Min(candle.Open, candle.Close))
+	ha.previous = &candle
+	return &candle
+}
+
+// CheckBars reports whether bars of barType can be built with threshold, which only volume,
+// dollar and tick bars use.
+func CheckBars(barType BarType, threshold float64) error {
+	switch barType {
+	case TimeBar, HeikinAshiBar:
+		return nil
+	}
+	_, err := NewThresholdBars(barType, threshold)
+	return err
+}
+
+// SubscribeBars subscribes to bars of barType: time bars of interval, volume, dollar or tick bars
+// closed at threshold, or Heikin-Ashi candles of interval warmed up on the bars in the container.
+func (srv *KLineService) SubscribeBars(barType BarType, interval string, threshold float64, handler func(event *Kline)) (int64, error) {
+	switch barType {
+	case TimeBar:
+		return srv.SubscribeInterval(interval, handler)
+	case HeikinAshiBar:
+		width, err := IntervalMs(interval)
+		if err != nil {
+			return 0, err
+		}
+		tail, err := srv.Tail()
+		if err != nil {
+			return 0, err
+		}
+		// Replay the bars before the current one only to settle the candles
+		live := tail.OpenTime - tail.OpenTime%width
+		ha := &HeikinAshi{}
+		return srv.SubscribeSince(live-heikinAshiWarmup*width, interval, func(bar *Kline) {
+			candle := ha.Add(bar)
+			if candle.OpenTime >= live {
+				handler(candle)
+			}
+		})
+	}
+	bars, err := NewThresholdBars(barType, threshold)
+	if err != nil {
+		return 0, err
+	}
+	return srv.SubscribeStateful(func(kline *Kline) {
+		for _, bar := range bars.Add(kline) {
+			handler(bar)
+		}
+	}), nil
+}
+
+// QueryBars passes the bars of barType built from the klines opened in [start, end],
+// a volume, dollar or tick bar still short of threshold at end is left out.
+func (srv *KLineService) QueryBars(start int64, end int64, barType BarType, interval string, threshold float64, handler func(event *Kline) error) error {
+	switch barType {
+	case TimeBar:
+		return srv.QueryInterval(start, end, interval, handler)
+	case HeikinAshiBar:
+		width, err := IntervalMs(interval)
+		if err != nil {
+			return err
+		}
+		first := start - start%width
+		ha := &HeikinAshi{}
+		return srv.QueryInterval(first-heikinAshiWarmup*width, end, interval, func(bar *Kline) error {
+			candle := ha.Add(bar)
+			if candle.OpenTime < first {
+				return nil
+			}
+			return handler(candle)
+		})
+	}
+	bars, err := NewThresholdBars(barType, threshold)
+	if err != nil {
+		return err
+	}
+	return srv.Query(start, end, func(kline *Kline) error {
+		for _, bar := range bars.Add(kline) {
+			if err := handler(bar); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestThresholdBars(t *testing.T) {
+	// Every 1s kline has volume 1, quote volume price and 2 trades
+	tests := []struct {
+		barType   BarType
+		threshold float64
+		expected  int
+	}{
+		{VolumeBar, 3, 3},   // 3 klines per bar
+		{DollarBar, 250, 3}, // 100 + 101 + 102 reaches 250 on the third
+		{TickBar, 5, 3},     // 6 trades after 3 klines
+		{VolumeBar, 2.5, 3}, // Overshoots to whole klines
+		{TickBar, 100, 0},   // Never reached
+	}
+	for _, test := range tests {
+		bars, err := NewThresholdBars(test.barType, test.threshold)
+		if err != nil {
+			t.Fatalf("Error creating bars: %v", err)
+		}
+		emitted := []*Kline{}
+		for i := int64(0); i < 9; i++ {
+			emitted = append(emitted, bars.Add(newSecondKline(i*1000, float64(100+i)))...)
+		}
+		if test.expected == 0 {
+			if len(emitted) != 0 {
+				t.Errorf("Expected no bar of type %d at %v, got %d", test.barType, test.threshold, len(emitted))
+			}
+			continue
+		}
+		if len(emitted) != 9/test.expected {
+			t.Fatalf("Expected %d bars of type %d at %v, got %d", 9/test.expected, test.barType, test.threshold, len(emitted))
+		}
+		bar := emitted[1]
+		if bar.OpenTime != 3000 || bar.CloseTime != 5999 || bar.Open != 103 || bar.Close != 105 || bar.High != 106 || bar.Low != 102 {
+			t.Errorf("Unexpected second bar %+v", bar)
+		}
+		if bar.Volume != 3 || bar.TradeNum != 6 || !bar.IsFinal {
+			t.Errorf("Unexpected second bar volume %v trades %d final %v", bar.Volume, bar.TradeNum, bar.IsFinal)
+		}
+	}
+	if _, err := NewThresholdBars(VolumeBar, 0); err != errThresholdNotPositive {
+		t.Errorf("Expected errThresholdNotPositive, got %v", err)
+	}
+	if _, err := NewThresholdBars(HeikinAshiBar, 1); err != errBarTypeNotSupport {
+		t.Errorf("Expected errBarTypeNotSupport, got %v", err)
+	}
+}
+
+func TestHeikinAshi(t *testing.T) {
+	ha := &HeikinAshi{}
+	first := ha.Add(&Kline{Open: 10, High: 14, Low: 8, Close: 12})
+	if first.Open != 11 || first.Close != 11 || first.High != 14 || first.Low != 8 {
+		t.Errorf("Unexpected first candle %+v", first)
+	}
+	second := ha.Add(&Kline{Open: 12, High: 20, Low: 11, Close: 17})
+	if second.Open != 11 || second.Close != 15 || second.High != 20 || second.Low != 11 {
+		t.Errorf("Unexpected second candle %+v", second)
+	}
+}
+
+func TestKLineServiceQueryBars(t *testing.T) {
+	srv := NewKLineService("BTCUSDT", 200, &fakeExchange{}, SubscriberOptions{})
+	start := fakeNow - fakeNow%60_000 - 120_000
+	for openTime := start; openTime < start+180_000; openTime += 1000 {
+		srv.pushBack(newSecondKline(openTime, 1))
+	}
+	volumeBars := []*Kline{}
+	err := srv.QueryBars(start, start+9000, VolumeBar, "", 4, func(bar *Kline) error {
+		volumeBars = append(volumeBars, bar)
+		return nil
+	})
+	if err != nil || len(volumeBars) != 2 || volumeBars[1].OpenTime != start+4000 || volumeBars[1].CloseTime != start+7999 {
+		t.Errorf("Expected 2 volume bars of 4 klines, got %d %v", len(volumeBars), err)
+	}
+
+	// Candles before start only settle the open
+	candles := []*Kline{}
+	err = srv.QueryBars(start+60_000, start+179_999, HeikinAshiBar, "1m", 0, func(bar *Kline) error {
+		candles = append(candles, bar)
+		return nil
+	})
+	if err != nil || len(candles) != 2 || candles[0].OpenTime != start+60_000 {
+		t.Fatalf("Expected 2 candles from %d, got %d %v", start+60_000, len(candles), err)
+	}
+	if candles[0].Open != 1 || candles[0].Close != 1 {
+		t.Errorf("Expected a flat candle at 1, got %+v", candles[0])
+	}
+
+	errStop := errors.New("stop")
+	n := 0
+	err = srv.QueryBars(start, start+179_999, TimeBar, "1m", 0, func(*Kline) error { n++; return errStop })
+	if err != errStop || n != 1 {
+		t.Errorf("Expected the query to stop at the first error, got %v after %d bars", err, n)
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// NewBinanceBookExchange creates the Binance adapter for OrderBookService, see NewBinanceExchange for the URLs.
+func NewBinanceBookExchange(apiURL, wsURL string) BookExchange {
+	return newBinanceExchange(apiURL, wsURL)
+}
+
+func (ex *binanceExchange) DepthSnapshot(ctx context.Context, symbol string, limit int) (*BookSnapshot, error) {
+	depth, err := ex.client.NewDepthService().Symbol(strings.ToUpper(symbol)).Limit(limit).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bids, err := convertFromPriceLevels(depth.Bids)
+	if err != nil {
+		return nil, err
+	}
+	asks, err := convertFromPriceLevels(depth.Asks)
+	if err != nil {
+		return nil, err
+	}
+	return &BookSnapshot{
+		LastUpdateID: depth.LastUpdateID,
+		Bids:         bids,
+		Asks:         asks,
+	}, nil
+}
+
+// wsDepthEvent is a diff of the depth stream, levels arrive as [price, quantity] pairs.
+type wsDepthEvent struct {
+	Time          int64       `json:"E"`
+	FirstUpdateID int64       `json:"U"`
+	LastUpdateID  int64       `json:"u"`
+	Bids          [][2]string `json:"b"`
+	Asks          [][2]string `json:"a"`
+}
+
+func (ex *binanceExchange) DepthStream(symbol string, handler func(*DepthUpdate), errHandler func(error)) (doneC, stopC chan struct{}, err error) {
+	wsDepthHandler := func(message []byte) {
+		event := new(wsDepthEvent)
+		if err := json.Unmarshal(message, event); err != nil {
+			errHandler(err)
+			return
+		}
+		bids, err := convertFromPriceLevels(convertFromLevelPairs(event.Bids))
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		asks, err := convertFromPriceLevels(convertFromLevelPairs(event.Asks))
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(&DepthUpdate{
+			FirstUpdateID: event.FirstUpdateID,
+			LastUpdateID:  event.LastUpdateID,
+			Time:          event.Time,
+			Bids:          bids,
+			Asks:          asks,
+		})
+	}
+	return wsServe(ex.wsURL, symbol, "depth@100ms", wsDepthHandler, errHandler)
+}
+
+func convertFromLevelPairs(pairs [][2]string) []common.PriceLevel {
+	levels := make([]common.PriceLevel, len(pairs))
+	for i, pair := range pairs {
+		levels[i] = common.PriceLevel{Price: pair[0], Quantity: pair[1]}
+	}
+	return levels
+}
+
+func convertFromPriceLevels(bLevels []common.PriceLevel) ([]PriceLevel, error) {
+	levels := make([]PriceLevel, 0, len(bLevels))
+	for _, bLevel := range bLevels {
+		price, err := strconv.ParseFloat(bLevel.Price, 64)
+		if err != nil {
+			return nil, err
+		}
+		quantity, err := strconv.ParseFloat(bLevel.Quantity, 64)
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, PriceLevel{Price: price, Quantity: quantity})
+	}
+	return levels, nil
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/gorilla/websocket"
+)
+
+const defaultWsURL = "wss://stream.binance.com:9443/ws"
+
+type binanceExchange struct {
+	client *binance.Client
+	wsURL  string
+}
+
+// NewBinanceExchange creates the Binance adapter, empty URLs keep the production endpoints.
+func NewBinanceExchange(apiURL, wsURL string) Exchange {
+	return newBinanceExchange(apiURL, wsURL)
+}
+
+func newBinanceExchange(apiURL, wsURL string) *binanceExchange {
+	client := binance.NewClient("", "")
+	if apiURL != "" {
+		client.BaseURL = apiURL
+	}
+	if wsURL == "" {
+		wsURL = defaultWsURL
+	}
+	return &binanceExchange{
+		client: client,
+		wsURL:  wsURL,
+	}
+}
+
+func (ex *binanceExchange) KlinePage(ctx context.Context, symbol, interval string, startTime, endTime int64, limit int) ([]*Kline, error) {
+	ksrv := ex.client.NewKlinesService()
+	ksrv.Symbol(strings.ToUpper(symbol))
+	ksrv.Interval(interval)
+	ksrv.Limit(limit)
+	ksrv.StartTime(startTime)
+	ksrv.EndTime(endTime)
+	bKlines, err := ksrv.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertFromKlines(bKlines)
+}
+
+func (ex *binanceExchange) RecentKlines(ctx context.Context, symbol, interval string, limit int) ([]*Kline, error) {
+	ksrv := ex.client.NewKlinesService()
+	ksrv.Symbol(strings.ToUpper(symbol))
+	ksrv.Interval(interval)
+	ksrv.Limit(limit)
+	bKlines, err := ksrv.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertFromKlines(bKlines)
+}
+
+func (ex *binanceExchange) KlineStream(symbol, interval string, handler func(*Kline), errHandler func(error)) (doneC, stopC chan struct{}, err error) {
+	wsKlineHandler := func(message []byte) {
+		event := new(binance.WsKlineEvent)
+		if err := json.Unmarshal(message, event); err != nil {
+			errHandler(err)
+			return
+		}
+		kline, err := convertFromWsKline(&event.Kline)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(kline)
+	}
+	return wsServe(ex.wsURL, symbol, "kline_"+interval, wsKlineHandler, errHandler)
+}
+
+// wsServe streams the raw messages of one stream of a symbol from the endpoint wsURL.
+// It mirrors the go-binance serve functions, which only dial the package level endpoint.
+// doneC is closed once the connection is gone, closing stopC disconnects without an error.
+func wsServe(wsURL, symbol, stream string, handler func([]byte), errHandler func(error)) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@%s", wsURL, strings.ToLower(symbol), stream)
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 45 * time.Second,
+	}
+	conn, _, err := dialer.Dial(endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	conn.SetReadLimit(655350)
+	doneC = make(chan struct{})
+	stopC = make(chan struct{})
+	go func() {
+		defer close(doneC)
+		stopped := make(chan struct{})
+		go func() {
+			select {
+			case <-stopC:
+				close(stopped)
+			case <-doneC:
+			}
+			conn.Close()
+		}()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				select {
+				case <-stopped:
+				default:
+					errHandler(err)
+				}
+				return
+			}
+			handler(message)
+		}
+	}()
+	return doneC, stopC, nil
+}
+
+func convertFromKlines(bKlines []*binance.Kline) ([]*Kline, error) {
+	klines := make([]*Kline, 0, len(bKlines))
+	for _, bKline := range bKlines {
+		kline, err := convertFromKline(bKline)
+		if err != nil {
+			return nil, err
+		}
+		klines = append(klines, kline)
+	}
+	return klines, nil
+}
+
+func convertFromKline(bKline *binance.Kline) (*Kline, error) {
+	// Helper function to convert string to float64
+	strToFloat64 := func(s string) (float64, error) {
+		return strconv.ParseFloat(s, 64)
+	}
+
+	open, err := strToFloat64(bKline.Open)
+	if err != nil {
+		return nil, err
+	}
+
+	high, err := strToFloat64(bKline.High)
+	if err != nil {
+		return nil, err
+	}
+
+	low, err := strToFloat64(bKline.Low)
+	if err != nil {
+		return nil, err
+	}
+
+	close, err := strToFloat64(bKline.Close)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := strToFloat64(bKline.Volume)
+	if err != nil {
+		return nil, err
+	}
+
+	quoteAssetVolume, err := strToFloat64(bKline.QuoteAssetVolume)
+	if err != nil {
+		return nil, err
+	}
+
+	takerBuyBaseAssetVolume, err := strToFloat64(bKline.TakerBuyBaseAssetVolume)
+	if err != nil {
+		return nil, err
+	}
+
+	takerBuyQuoteAssetVolume, err := strToFloat64(bKline.TakerBuyQuoteAssetVolume)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Kline{
+		OpenTime:                 bKline.OpenTime,
+		Open:                     open,
+		High:                     high,
+		Low:                      low,
+		Close:                    close,
+		Volume:                   volume,
+		CloseTime:                bKline.CloseTime,
+		QuoteAssetVolume:         quoteAssetVolume,
+		TradeNum:                 bKline.TradeNum,
+		TakerBuyBaseAssetVolume:  takerBuyBaseAssetVolume,
+		TakerBuyQuoteAssetVolume: takerBuyQuoteAssetVolume,
+		IsFinal:                  bKline.CloseTime < time.Now().UnixMilli(), // REST includes the kline in progress
+	}, nil
+}
+
+func convertFromWsKline(wsKline *binance.WsKline) (*Kline, error) {
+	// Helper function to convert string to float64
+	strToFloat64 := func(s string) (float64, error) {
+		return strconv.ParseFloat(s, 64)
+	}
+
+	open, err := strToFloat64(wsKline.Open)
+	if err != nil {
+		return nil, err
+	}
+
+	high, err := strToFloat64(wsKline.High)
+	if err != nil {
+		return nil, err
+	}
+
+	low, err := strToFloat64(wsKline.Low)
+	if err != nil {
+		return nil, err
+	}
+
+	close, err := strToFloat64(wsKline.Close)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := strToFloat64(wsKline.Volume)
+	if err != nil {
+		return nil, err
+	}
+
+	quoteVolume, err := strToFloat64(wsKline.QuoteVolume)
+	if err != nil {
+		return nil, err
+	}
+
+	activeBuyVolume, err := strToFloat64(wsKline.ActiveBuyVolume)
+	if err != nil {
+		return nil, err
+	}
+
+	activeBuyQuoteVolume, err := strToFloat64(wsKline.ActiveBuyQuoteVolume)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Kline{
+		OpenTime:                 wsKline.StartTime,
+		Open:                     open,
+		High:                     high,
+		Low:                      low,
+		Close:                    close,
+		Volume:                   volume,
+		CloseTime:                wsKline.EndTime,
+		QuoteAssetVolume:         quoteVolume,
+		TradeNum:                 wsKline.TradeNum,
+		TakerBuyBaseAssetVolume:  activeBuyVolume,
+		TakerBuyQuoteAssetVolume: activeBuyQuoteVolume,
+		IsFinal:                  wsKline.IsFinal,
+	}, nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+const defaultFuturesWsURL = "wss://fstream.binance.com/ws"
+
+type binanceFuturesExchange struct {
+	client *futures.Client
+	wsURL  string
+}
+
+// NewBinanceFuturesExchange creates the USD-M adapter, empty URLs keep the production endpoints.
+func NewBinanceFuturesExchange(apiURL, wsURL string) FuturesExchange {
+	client := futures.NewClient("", "")
+	if apiURL != "" {
+		client.BaseURL = apiURL
+	}
+	if wsURL == "" {
+		wsURL = defaultFuturesWsURL
+	}
+	return &binanceFuturesExchange{
+		client: client,
+		wsURL:  wsURL,
+	}
+}
+
+func (ex *binanceFuturesExchange) FuturesKlinePage(ctx context.Context, symbol string, priceType PriceType, interval string, startTime, endTime int64, limit int) ([]*Kline, error) {
+	symbol = strings.ToUpper(symbol)
+	var fKlines []*futures.Kline
+	var err error
+	switch priceType {
+	case ContractPrice:
+		fKlines, err = ex.client.NewKlinesService().Symbol(symbol).Interval(interval).
+			StartTime(startTime).EndTime(endTime).Limit(limit).Do(ctx)
+	case MarkPrice:
+		fKlines, err = ex.client.NewMarkPriceKlinesService().Symbol(symbol).Interval(interval).
+			StartTime(startTime).EndTime(endTime).Limit(limit).Do(ctx)
+	case IndexPrice:
+		fKlines, err = ex.client.NewIndexPriceKlinesService().Pair(symbol).Interval(interval).
+			StartTime(startTime).EndTime(endTime).Limit(limit).Do(ctx)
+	default:
+		return nil, errPriceTypeNotSupport
+	}
+	if err != nil {
+		return nil, err
+	}
+	klines := make([]*Kline, 0, len(fKlines))
+	for _, fKline := range fKlines {
+		// Same layout as a spot kline, mark and index klines leave the volumes at zero
+		kline, err := convertFromKline(&binance.Kline{
+			OpenTime:                 fKline.OpenTime,
+			Open:                     fKline.Open,
+			High:                     fKline.High,
+			Low:                      fKline.Low,
+			Close:                    fKline.Close,
+			Volume:                   orZero(fKline.Volume),
+			CloseTime:                fKline.CloseTime,
+			QuoteAssetVolume:         orZero(fKline.QuoteAssetVolume),
+			TradeNum:                 fKline.TradeNum,
+			TakerBuyBaseAssetVolume:  orZero(fKline.TakerBuyBaseAssetVolume),
+			TakerBuyQuoteAssetVolume: orZero(fKline.TakerBuyQuoteAssetVolume),
+		})
+		if err != nil {
+			return nil, err
+		}
+		klines = append(klines, kline)
+	}
+	return klines, nil
+}
+
+func (ex *binanceFuturesExchange) FundingRatePage(ctx context.Context, symbol string, startTime, endTime int64, limit int) ([]*FundingRate, error) {
+	fsrv := ex.client.NewFundingRateService().Symbol(strings.ToUpper(symbol)).Limit(limit)
+	if startTime != 0 {
+		fsrv.StartTime(startTime)
+	}
+	if endTime != 0 {
+		fsrv.EndTime(endTime)
+	}
+	fRates, err := fsrv.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rates := make([]*FundingRate, 0, len(fRates))
+	for _, fRate := range fRates {
+		rate, err := strconv.ParseFloat(fRate.FundingRate, 64)
+		if err != nil {
+			return nil, err
+		}
+		markPrice, err := strconv.ParseFloat(orZero(fRate.MarkPrice), 64)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, &FundingRate{
+			FundingTime: fRate.FundingTime,
+			Rate:        rate,
+			MarkPrice:   markPrice,
+		})
+	}
+	return rates, nil
+}
+
+func (ex *binanceFuturesExchange) MarkPriceStream(symbol string, handler func(*MarkPriceUpdate), errHandler func(error)) (doneC, stopC chan struct{}, err error) {
+	wsMarkPriceHandler := func(message []byte) {
+		event := new(futures.WsMarkPriceEvent)
+		if err := json.Unmarshal(message, event); err != nil {
+			errHandler(err)
+			return
+		}
+		markPrice, err := convertFromWsMarkPrice(event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(markPrice)
+	}
+	return wsServe(ex.wsURL, symbol, "markPrice@1s", wsMarkPriceHandler, errHandler)
+}
+
+func convertFromWsMarkPrice(event *futures.WsMarkPriceEvent) (*MarkPriceUpdate, error) {
+	markPrice := &MarkPriceUpdate{
+		Time:            event.Time,
+		NextFundingTime: event.NextFundingTime,
+	}
+	fields := []struct {
+		s string
+		f *float64
+	}{
+		{event.MarkPrice, &markPrice.MarkPrice},
+		{event.IndexPrice, &markPrice.IndexPrice},
+		{event.EstimatedSettlePrice, &markPrice.EstimatedSettlePrice},
+		{event.FundingRate, &markPrice.FundingRate},
+	}
+	for _, field := range fields {
+		value, err := strconv.ParseFloat(orZero(field.s), 64)
+		if err != nil {
+			return nil, err
+		}
+		*field.f = value
+	}
+	return markPrice, nil
+}
+
+// orZero reads a number Binance leaves empty for some series as zero.
+func orZero(s string) string {
+	if s == "" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+)
+
+// NewBinanceTickerExchange creates the Binance adapter for BookTickerService, see NewBinanceExchange for the URLs.
+func NewBinanceTickerExchange(apiURL, wsURL string) TickerExchange {
+	return newBinanceExchange(apiURL, wsURL)
+}
+
+func (ex *binanceExchange) BookTickerStream(symbol string, handler func(*BookTicker), errHandler func(error)) (doneC, stopC chan struct{}, err error) {
+	wsBookTickerHandler := func(message []byte) {
+		event := new(binance.WsBookTickerEvent)
+		if err := json.Unmarshal(message, event); err != nil {
+			errHandler(err)
+			return
+		}
+		ticker, err := convertFromWsBookTicker(event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(ticker)
+	}
+	return wsServe(ex.wsURL, symbol, "bookTicker", wsBookTickerHandler, errHandler)
+}
+
+func convertFromWsBookTicker(event *binance.WsBookTickerEvent) (*BookTicker, error) {
+	ticker := &BookTicker{
+		UpdateID: event.UpdateID,
+		Time:     time.Now().UnixMilli(),
+	}
+	fields := []struct {
+		s string
+		f *float64
+	}{
+		{event.BestBidPrice, &ticker.BidPrice},
+		{event.BestBidQty, &ticker.BidQuantity},
+		{event.BestAskPrice, &ticker.AskPrice},
+		{event.BestAskQty, &ticker.AskQuantity},
+	}
+	for _, field := range fields {
+		value, err := strconv.ParseFloat(field.s, 64)
+		if err != nil {
+			return nil, err
+		}
+		*field.f = value
+	}
+	return ticker, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2"
+)
+
+// NewBinanceTradeExchange creates the Binance adapter for TradeService, see NewBinanceExchange for the URLs.
+func NewBinanceTradeExchange(apiURL, wsURL string) TradeExchange {
+	return newBinanceExchange(apiURL, wsURL)
+}
+
+func (ex *binanceExchange) TradePage(ctx context.Context, symbol string, kind TradeKind, fromID int64, limit int) ([]*Trade, error) {
+	symbol = strings.ToUpper(symbol)
+	switch kind {
+	case AggTrade:
+		bTrades, err := ex.client.NewAggTradesService().Symbol(symbol).FromID(fromID).Limit(limit).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return convertFromAggTrades(bTrades)
+	case RawTrade:
+		bTrades, err := ex.client.NewHistoricalTradesService().Symbol(symbol).FromID(fromID).Limit(limit).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return convertFromTrades(bTrades)
+	}
+	return nil, errTradeKindNotSupport
+}
+
+func (ex *binanceExchange) RecentTrades(ctx context.Context, symbol string, kind TradeKind, limit int) ([]*Trade, error) {
+	symbol = strings.ToUpper(symbol)
+	switch kind {
+	case AggTrade:
+		bTrades, err := ex.client.NewAggTradesService().Symbol(symbol).Limit(limit).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return convertFromAggTrades(bTrades)
+	case RawTrade:
+		bTrades, err := ex.client.NewRecentTradesService().Symbol(symbol).Limit(limit).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return convertFromTrades(bTrades)
+	}
+	return nil, errTradeKindNotSupport
+}
+
+func (ex *binanceExchange) TradeStream(symbol string, kind TradeKind, handler func(*Trade), errHandler func(error)) (doneC, stopC chan struct{}, err error) {
+	switch kind {
+	case AggTrade:
+		return wsServe(ex.wsURL, symbol, "aggTrade", func(message []byte) {
+			event := new(binance.WsAggTradeEvent)
+			if err := json.Unmarshal(message, event); err != nil {
+				errHandler(err)
+				return
+			}
+			trade, err := convertFromTrade(event.AggTradeID, event.Price, event.Quantity, event.TradeTime, event.IsBuyerMaker)
+			if err != nil {
+				errHandler(err)
+				return
+			}
+			trade.FirstTradeID = event.FirstBreakdownTradeID
+			trade.LastTradeID = event.LastBreakdownTradeID
+			handler(trade)
+		}, errHandler)
+	case RawTrade:
+		return wsServe(ex.wsURL, symbol, "trade", func(message []byte) {
+			event := new(binance.WsTradeEvent)
+			if err := json.Unmarshal(message, event); err != nil {
+				errHandler(err)
+				return
+			}
+			trade, err := convertFromTrade(event.TradeID, event.Price, event.Quantity, event.TradeTime, event.IsBuyerMaker)
+			if err != nil {
+				errHandler(err)
+				return
+			}
+			handler(trade)
+		}, errHandler)
+	}
+	return nil, nil, errTradeKindNotSupport
+}
+
+func convertFromAggTrades(bTrades []*binance.AggTrade) ([]*Trade, error) {
+	trades := make([]*Trade, 0, len(bTrades))
+	for _, bTrade := range bTrades {
+		trade, err := convertFromTrade(bTrade.AggTradeID, bTrade.Price, bTrade.Quantity, bTrade.Timestamp, bTrade.IsBuyerMaker)
+		if err != nil {
+			return nil, err
+		}
+		trade.FirstTradeID = bTrade.FirstTradeID
+		trade.LastTradeID = bTrade.LastTradeID
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+func convertFromTrades(bTrades []*binance.Trade) ([]*Trade, error) {
+	trades := make([]*Trade, 0, len(bTrades))
+	for _, bTrade := range bTrades {
+		trade, err := convertFromTrade(bTrade.ID, bTrade.Price, bTrade.Quantity, bTrade.Time, bTrade.IsBuyerMaker)
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+// convertFromTrade builds an individual trade, aggregate trades overwrite the trade id range.
+func convertFromTrade(id int64, price, quantity string, time int64, isBuyerMaker bool) (*Trade, error) {
+	p, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return nil, err
+	}
+	q, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &Trade{
+		ID:           id,
+		Price:        p,
+		Quantity:     q,
+		FirstTradeID: id,
+		LastTradeID:  id,
+		Time:         time,
+		IsBuyerMaker: isBuyerMaker,
+	}, nil
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/BullionBear/crypto-feed/pkg/linkedlist"
+	log "github.com/sirupsen/logrus"
+)
+
+var errBookTickerNotExist = errors.New("book ticker is not existed")
+
+// spreadCarryLimit is the most seconds without an update that repeat the last book ticker,
+// longer silences, e.g. a dropped stream, are left out of the series.
+const spreadCarryLimit = 60
+
+// spreadSeries rolls book tickers up into one Spread per second.
+type spreadSeries struct {
+	current *Spread // Second in progress
+	sum     float64 // Spreads added to current
+	updated int64   // Last second with an update
+}
+
+func (series *spreadSeries) add(ticker *BookTicker) []*Spread {
+	openTime := ticker.Time - ticker.Time%1000
+	closed := series.close(openTime)
+	if series.current == nil {
+		series.current = &Spread{OpenTime: openTime}
+	}
+	spread := series.current
+	spread.Bid = ticker.BidPrice
+	spread.Ask = ticker.AskPrice
+	spread.Mid = (ticker.BidPrice + ticker.AskPrice) / 2
+	spread.Spread = ticker.AskPrice - ticker.BidPrice
+	spread.Updates++
+	series.sum += spread.Spread
+	spread.MeanSpread = series.sum / float64(spread.Updates)
+	return closed
+}
+
+// close returns the seconds opened before openTime. A second without update repeats the
+// book ticker before it, up to spreadCarryLimit seconds after the last update.
+func (series *spreadSeries) close(openTime int64) []*Spread {
+	closed := []*Spread{}
+	for series.current != nil && series.current.OpenTime < openTime {
+		last := series.current
+		closed = append(closed, last)
+		if last.Updates > 0 {
+			series.updated = last.OpenTime
+		}
+		series.current, series.sum = nil, 0
+		if next := last.OpenTime + 1000; next-series.updated <= spreadCarryLimit*1000 {
+			series.current = &Spread{
+				OpenTime:   next,
+				Bid:        last.Bid,
+				Ask:        last.Ask,
+				Mid:        last.Mid,
+				Spread:     last.Spread,
+				MeanSpread: last.Spread,
+			}
+		}
+	}
+	return closed
+}
+
+// BookTickerService streams the best bid and ask of a symbol and keeps the latest length seconds
+// of them as a Spread series keyed like the 1s klines.
+type BookTickerService struct {
+	symbol string
+	length int64
+	// Container
+	container linkedlist.IndexLinkedList[Spread]
+	series    spreadSeries
+	latest    atomic.Pointer[BookTicker]
+	// Dependencies
+	exchange TickerExchange
+	// Subscriber
+	*subscriberRegistry[BookTicker]
+	// Dynamic varaible
+	status Status
+	errCh  chan struct{}
+	// pipeline control
+	seriesMutex sync.Mutex
+}
+
+func NewBookTickerService(symbol string, length int64, exchange TickerExchange, subOpts SubscriberOptions) *BookTickerService {
+	return &BookTickerService{
+		symbol:             symbol,
+		length:             length,
+		container:          *linkedlist.NewIndexedLinkedList[Spread](),
+		exchange:           exchange,
+		subscriberRegistry: newSubscriberRegistry[BookTicker]("book ticker", symbol, subOpts),
+		status:             StatusCreated,
+		errCh:              make(chan struct{}),
+	}
+}
+
+func (srv *BookTickerService) Run() error {
+	srv.status = StatusRunning
+	go srv.subscribeBookTicker()
+	go srv.closeSpread()
+	go func() {
+		for {
+			select {
+			case <-srv.errCh:
+				srv.status = StatusError
+				log.Errorf("some goroutine dead, need to check")
+			case <-srv.stopCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (srv *BookTickerService) Symbol() string {
+	return srv.symbol
+}
+
+func (srv *BookTickerService) Status() Status {
+	return srv.status
+}
+
+func (srv *BookTickerService) Latest() (BookTicker, error) {
+	ticker := srv.latest.Load()
+	if ticker == nil {
+		return BookTicker{}, errBookTickerNotExist
+	}
+	return *ticker, nil
+}
+
+func (srv *BookTickerService) SpreadAt(openTime int64) (Spread, error) {
+	return srv.container.Get(openTime)
+}
+
+func (srv *BookTickerService) Subscribe(handler func(event *BookTicker)) int64 {
+	return srv.subscribe(handler)
+}
+
+func (srv *BookTickerService) subscribeBookTicker() {
+	var wsBookTickerHandler = func(ticker *BookTicker) {
+		srv.pushBookTicker(ticker)
+	}
+	var errHandler = func(err error) {
+		log.Errorf("handle error of wsBookTicker %s", err.Error())
+	}
+	srv.serve(func() (doneC, stopC chan struct{}, err error) {
+		return srv.exchange.BookTickerStream(srv.symbol, wsBookTickerHandler, errHandler)
+	})
+}
+
+// closeSpread closes every second once it is over, also when no book ticker arrives after it.
+func (srv *BookTickerService) closeSpread() {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+	for {
+		var now time.Time
+		select {
+		case now = <-ticker.C:
+		case <-srv.stopCh:
+			return
+		}
+		openTime := now.UnixMilli() - now.UnixMilli()%1000
+		srv.seriesMutex.Lock()
+		srv.pushSpread(srv.series.close(openTime))
+		srv.seriesMutex.Unlock()
+	}
+}
+
+func (srv *BookTickerService) pushBookTicker(ticker *BookTicker) {
+	srv.latest.Store(ticker)
+	srv.seriesMutex.Lock()
+	srv.pushSpread(srv.series.add(ticker))
+	srv.seriesMutex.Unlock()
+	srv.publish(ticker)
+}
+
+// pushSpread appends closed seconds and drops the oldest beyond length, the caller holds seriesMutex.
+func (srv *BookTickerService) pushSpread(spreads []*Spread) {
+	for _, spread := range spreads {
+		if err := srv.container.PushBack(spread.OpenTime, *spread); err != nil {
+			log.Errorf("Fail to push spread %+v: %s", spread, err.Error())
+		}
+	}
+	for srv.container.Size() > srv.length {
+		if _, err := srv.container.PopFront(); err != nil {
+			log.Errorf("fail to pop spread %s", err.Error())
+			break
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// BookTickerManager owns one BookTickerService per symbol.
+type BookTickerManager struct {
+	exchange TickerExchange
+	subOpts  SubscriberOptions
+	services map[string]*BookTickerService
+	mutex    sync.RWMutex
+}
+
+func NewBookTickerManager(exchange TickerExchange, subOpts SubscriberOptions) *BookTickerManager {
+	return &BookTickerManager{
+		exchange: exchange,
+		subOpts:  subOpts,
+		services: make(map[string]*BookTickerService),
+	}
+}
+
+func (m *BookTickerManager) Add(symbol string, length int64) (*BookTickerService, error) {
+	key := strings.ToUpper(symbol)
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.services[key]; exists {
+		return nil, errSymbolExist
+	}
+	srv := NewBookTickerService(key, length, m.exchange, m.subOpts)
+	m.services[key] = srv
+	return srv, nil
+}
+
+func (m *BookTickerManager) Get(symbol string) (*BookTickerService, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	srv, exists := m.services[strings.ToUpper(symbol)]
+	if !exists {
+		return nil, errSymbolNotExist
+	}
+	return srv, nil
+}
+
+func (m *BookTickerManager) Symbols() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	result := make([]string, 0, len(m.services))
+	for symbol := range m.services {
+		result = append(result, symbol)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (m *BookTickerManager) Run() {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for symbol, srv := range m.services {
+		log.Infof("Start book ticker service of %s", symbol)
+		go srv.Run()
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func newBookTicker(updateID, receiveTime int64, bid, ask float64) *BookTicker {
+	return &BookTicker{UpdateID: updateID, Time: receiveTime, BidPrice: bid, BidQuantity: 1, AskPrice: ask, AskQuantity: 1}
+}
+
+func TestSpreadSeries(t *testing.T) {
+	series := &spreadSeries{}
+	closed := []*Spread{}
+	closed = append(closed, series.add(newBookTicker(1, 1000, 99, 101))...)
+	closed = append(closed, series.add(newBookTicker(2, 1500, 99, 103))...)
+	closed = append(closed, series.add(newBookTicker(3, 4200, 100, 101))...)
+	if len(closed) != 3 {
+		t.Fatalf("Expected seconds 1000 to 3000 closed, got %d", len(closed))
+	}
+	first := closed[0]
+	if first.OpenTime != 1000 || first.Bid != 99 || first.Ask != 103 || first.Mid != 101 || first.Spread != 4 || first.MeanSpread != 3 || first.Updates != 2 {
+		t.Errorf("Unexpected first second %+v", first)
+	}
+	// Seconds without update repeat the last book ticker
+	for i, spread := range closed[1:] {
+		if spread.OpenTime != int64(2000+i*1000) || spread.Spread != 4 || spread.MeanSpread != 4 || spread.Updates != 0 {
+			t.Errorf("Unexpected carried second %+v", spread)
+		}
+	}
+
+	// The carry stops spreadCarryLimit seconds after the last update
+	closed = series.close(4000 + 2*spreadCarryLimit*1000)
+	if len(closed) != spreadCarryLimit+1 || closed[len(closed)-1].OpenTime != 4000+spreadCarryLimit*1000 {
+		t.Errorf("Expected %d seconds up to %d, got %d", spreadCarryLimit+1, 4000+spreadCarryLimit*1000, len(closed))
+	}
+	if series.current != nil {
+		t.Errorf("Expected no second in progress, got %+v", series.current)
+	}
+}
+
+func TestBookTickerService(t *testing.T) {
+	srv := NewBookTickerService("BTCUSDT", 3, nil, SubscriberOptions{})
+	if _, err := srv.Latest(); err != errBookTickerNotExist {
+		t.Errorf("Expected errBookTickerNotExist, got %v", err)
+	}
+	tickerCh := make(chan int64, 10)
+	id := srv.Subscribe(func(ticker *BookTicker) {
+		tickerCh <- ticker.UpdateID
+	})
+	defer srv.Unsubscribe(id)
+	for i := int64(0); i < 5; i++ {
+		srv.pushBookTicker(newBookTicker(i, i*1000, 99, 100+float64(i)))
+	}
+	for i := int64(0); i < 5; i++ {
+		select {
+		case updateID := <-tickerCh:
+			if updateID != i {
+				t.Errorf("Expected book ticker %d, got %d", i, updateID)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timeout waiting for book ticker %d", i)
+		}
+	}
+	if latest, _ := srv.Latest(); latest.UpdateID != 4 {
+		t.Errorf("Expected latest book ticker 4, got %d", latest.UpdateID)
+	}
+	// Seconds 0 to 3 are closed, only the latest 3 are kept
+	if _, err := srv.SpreadAt(0); err == nil {
+		t.Errorf("Expected second 0 to be dropped")
+	}
+	spread, err := srv.SpreadAt(3000)
+	if err != nil || spread.Spread != 4 {
+		t.Errorf("Expected spread 4 at 3000, got %+v %v", spread, err)
+	}
+}
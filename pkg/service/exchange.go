@@ -0,0 +1,47 @@
+package service
+
+import "context"
+
+// Exchange is the venue adapter KLineService ingests klines from.
+type Exchange interface {
+	// KlinePage fetches at most limit klines whose open time lies within [startTime, endTime].
+	KlinePage(ctx context.Context, symbol, interval string, startTime, endTime int64, limit int) ([]*Kline, error)
+	RecentKlines(ctx context.Context, symbol, interval string, limit int) ([]*Kline, error)
+	// KlineStream streams live klines to handler until stopC is closed,
+	// doneC is closed once the stream terminates for any reason.
+	KlineStream(symbol, interval string, handler func(*Kline), errHandler func(error)) (doneC, stopC chan struct{}, err error)
+}
+
+// TradeExchange is the venue adapter TradeService ingests trades from, kind picks aggregate or individual trades.
+type TradeExchange interface {
+	// TradePage fetches at most limit trades whose id is at least fromID.
+	TradePage(ctx context.Context, symbol string, kind TradeKind, fromID int64, limit int) ([]*Trade, error)
+	RecentTrades(ctx context.Context, symbol string, kind TradeKind, limit int) ([]*Trade, error)
+	// TradeStream streams live trades to handler until stopC is closed.
+	TradeStream(symbol string, kind TradeKind, handler func(*Trade), errHandler func(error)) (doneC, stopC chan struct{}, err error)
+}
+
+// BookExchange is the venue adapter OrderBookService maintains a local order book from.
+type BookExchange interface {
+	// DepthSnapshot fetches the limit best levels of each side together with the id of the last update in them.
+	DepthSnapshot(ctx context.Context, symbol string, limit int) (*BookSnapshot, error)
+	// DepthStream streams the diffs of the order book to handler until stopC is closed.
+	DepthStream(symbol string, handler func(*DepthUpdate), errHandler func(error)) (doneC, stopC chan struct{}, err error)
+}
+
+// TickerExchange is the venue adapter BookTickerService ingests the best bid and ask from.
+type TickerExchange interface {
+	// BookTickerStream streams every change of the best bid or ask to handler until stopC is closed.
+	BookTickerStream(symbol string, handler func(*BookTicker), errHandler func(error)) (doneC, stopC chan struct{}, err error)
+}
+
+// FuturesExchange is the venue adapter FuturesService ingests a USD-M perpetual from.
+type FuturesExchange interface {
+	// FuturesKlinePage fetches at most limit klines of priceType whose open time lies within [startTime, endTime].
+	FuturesKlinePage(ctx context.Context, symbol string, priceType PriceType, interval string, startTime, endTime int64, limit int) ([]*Kline, error)
+	// FundingRatePage fetches at most limit funding rates settled within [startTime, endTime],
+	// the latest ones when both are 0.
+	FundingRatePage(ctx context.Context, symbol string, startTime, endTime int64, limit int) ([]*FundingRate, error)
+	// MarkPriceStream streams the mark price and funding rate every second until stopC is closed.
+	MarkPriceStream(symbol string, handler func(*MarkPriceUpdate), errHandler func(error)) (doneC, stopC chan struct{}, err error)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// FuturesManager owns one FuturesService per USD-M symbol.
+type FuturesManager struct {
+	exchange FuturesExchange
+	subOpts  SubscriberOptions
+	services map[string]*FuturesService
+	mutex    sync.RWMutex
+}
+
+func NewFuturesManager(exchange FuturesExchange, subOpts SubscriberOptions) *FuturesManager {
+	return &FuturesManager{
+		exchange: exchange,
+		subOpts:  subOpts,
+		services: make(map[string]*FuturesService),
+	}
+}
+
+func (m *FuturesManager) Add(symbol string, length int64) (*FuturesService, error) {
+	key := strings.ToUpper(symbol)
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.services[key]; exists {
+		return nil, errSymbolExist
+	}
+	srv := NewFuturesService(key, length, m.exchange, m.subOpts)
+	m.services[key] = srv
+	return srv, nil
+}
+
+func (m *FuturesManager) Get(symbol string) (*FuturesService, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	srv, exists := m.services[strings.ToUpper(symbol)]
+	if !exists {
+		return nil, errSymbolNotExist
+	}
+	return srv, nil
+}
+
+func (m *FuturesManager) Symbols() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	result := make([]string, 0, len(m.services))
+	for symbol := range m.services {
+		result = append(result, symbol)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (m *FuturesManager) Run() {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for symbol, srv := range m.services {
+		log.Infof("Start futures service of %s", symbol)
+		go srv.Run()
+	}
+}
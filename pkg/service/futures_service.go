@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/BullionBear/crypto-feed/pkg/linkedlist"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	errMarketNotSupport    = errors.New("market type is not supported")
+	errPriceTypeNotSupport = errors.New("price type is not supported")
+	errMarkPriceNotExist   = errors.New("mark price is not existed")
+)
+
+// MarketType selects the Binance market a symbol is served from.
+type MarketType string
+
+var (
+	SpotMarket = MarketType("spot")
+	USDMMarket = MarketType("usdm") // USD-M perpetual futures
+)
+
+func ParseMarketType(s string) (MarketType, error) {
+	switch market := MarketType(s); market {
+	case "":
+		return SpotMarket, nil
+	case SpotMarket, USDMMarket:
+		return market, nil
+	default:
+		return "", errMarketNotSupport
+	}
+}
+
+// PriceType selects one of the kline series of a perpetual.
+type PriceType int
+
+const (
+	ContractPrice PriceType = iota // Klines of the traded contract
+	MarkPrice                      // Klines of the mark price, volumes are zero
+	IndexPrice                     // Klines of the index price, volumes are zero
+)
+
+const (
+	futuresInterval     = "1m" // The narrowest kline Binance serves for USD-M
+	futuresPageLimit    = 1000
+	futuresPollInterval = 10 * time.Second
+	fundingPollInterval = 10 * time.Minute // Funding settles every few hours
+	fundingLength       = 1000             // Funding rates kept, a year at three a day
+)
+
+// FuturesService keeps the latest length 1m klines of the contract, mark and index price of a USD-M perpetual,
+// its funding rate history and the live mark price. Klines and funding rates are polled over REST,
+// the mark price and upcoming funding rate are streamed every second.
+type FuturesService struct {
+	symbol string
+	length int64
+	// Container
+	klines    map[PriceType]*linkedlist.IndexLinkedList[Kline]
+	funding   linkedlist.IndexLinkedList[FundingRate]
+	markPrice atomic.Pointer[MarkPriceUpdate]
+	// Dependencies
+	exchange FuturesExchange
+	// Subscriber
+	*subscriberRegistry[MarkPriceUpdate]
+	// Dynamic varaible
+	status Status
+	errCh  chan struct{}
+}
+
+func NewFuturesService(symbol string, length int64, exchange FuturesExchange, subOpts SubscriberOptions) *FuturesService {
+	return &FuturesService{
+		symbol: symbol,
+		length: length,
+		klines: map[PriceType]*linkedlist.IndexLinkedList[Kline]{
+			ContractPrice: linkedlist.NewIndexedLinkedList[Kline](),
+			MarkPrice:     linkedlist.NewIndexedLinkedList[Kline](),
+			IndexPrice:    linkedlist.NewIndexedLinkedList[Kline](),
+		},
+		funding:            *linkedlist.NewIndexedLinkedList[FundingRate](),
+		exchange:           exchange,
+		subscriberRegistry: newSubscriberRegistry[MarkPriceUpdate]("mark price", symbol, subOpts),
+		status:             StatusCreated,
+		errCh:              make(chan struct{}),
+	}
+}
+
+func (srv *FuturesService) Run() error {
+	srv.status = StatusInitializing
+	width := intervalMs[futuresInterval]
+	now := time.Now().UnixMilli()
+	start := now - now%width - (srv.length-1)*width
+	for priceType := range srv.klines {
+		if err := srv.requestKline(priceType, start); err != nil {
+			log.Errorf("Fail to retrieve historical klines of %s: %s", srv.symbol, err.Error())
+		}
+		go srv.pollKline(priceType, start)
+	}
+	log.Infof("Finish retrieve historical futures klines of %s", srv.symbol)
+	if err := srv.requestFunding(); err != nil {
+		log.Errorf("Fail to retrieve funding rates of %s: %s", srv.symbol, err.Error())
+	}
+	go srv.pollFunding()
+	go srv.subscribeMarkPrice()
+	srv.status = StatusRunning
+	go func() {
+		for {
+			select {
+			case <-srv.errCh:
+				srv.status = StatusError
+				log.Errorf("some goroutine dead, need to check")
+			case <-srv.stopCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (srv *FuturesService) Symbol() string {
+	return srv.symbol
+}
+
+func (srv *FuturesService) Status() Status {
+	return srv.status
+}
+
+func (srv *FuturesService) MarkPrice() (MarkPriceUpdate, error) {
+	markPrice := srv.markPrice.Load()
+	if markPrice == nil {
+		return MarkPriceUpdate{}, errMarkPriceNotExist
+	}
+	return *markPrice, nil
+}
+
+// Query rolls the closed 1m klines of priceType opened in [start, end] up into interval,
+// like KLineService.QueryInterval only bars fully covered by the container are passed.
+// An error of the handler stops the query and is returned.
+func (srv *FuturesService) Query(priceType PriceType, start int64, end int64, interval string, handler func(event *Kline) error) error {
+	container, exists := srv.klines[priceType]
+	if !exists {
+		return errPriceTypeNotSupport
+	}
+	if interval == "" {
+		interval = futuresInterval
+	}
+	step := intervalMs[futuresInterval]
+	agg, err := NewKlineAggregator(interval)
+	if err != nil {
+		return err
+	}
+	if agg.width < step {
+		return errIntervalNotSupport
+	}
+	head, err := container.Head()
+	if err != nil {
+		return err
+	}
+	tail, err := container.Tail()
+	if err != nil {
+		return err
+	}
+	first := agg.bucketStart(start)
+	if first < head.OpenTime {
+		first = agg.bucketStart(head.OpenTime-1) + agg.width
+	}
+	if end > tail.OpenTime {
+		end = tail.OpenTime // Also keeps the bucket of a far end, e.g. math.MaxInt64, from overflowing
+	}
+	last := agg.bucketStart(end) + agg.width - step
+	if last > tail.OpenTime {
+		last = tail.OpenTime
+	}
+	for key := first; key <= last; key += step {
+		kline, err := container.Get(key)
+		if err != nil {
+			continue // A minute without kline, the bar is built from the others
+		}
+		if !kline.IsFinal {
+			break
+		}
+		for _, bar := range agg.Add(&kline) {
+			if err := handler(bar); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// QueryFunding passes the funding rates settled within [start, end], an error of the handler stops it and is returned.
+func (srv *FuturesService) QueryFunding(start int64, end int64, handler func(event *FundingRate) error) error {
+	head, err := srv.funding.Head()
+	if err != nil {
+		return err
+	}
+	key := head.FundingTime
+	for {
+		rate, err := srv.funding.Get(key)
+		if err != nil {
+			return err
+		}
+		if rate.FundingTime > end {
+			return nil
+		}
+		if rate.FundingTime >= start {
+			if err := handler(&rate); err != nil {
+				return err
+			}
+		}
+		if key, err = srv.funding.Next(key); err != nil {
+			return nil // Past the tail
+		}
+	}
+}
+
+func (srv *FuturesService) Subscribe(handler func(event *MarkPriceUpdate)) int64 {
+	return srv.subscribe(handler)
+}
+
+// requestKline pages the klines of priceType forward from the open time from up to now.
+func (srv *FuturesService) requestKline(priceType PriceType, from int64) error {
+	container := srv.klines[priceType]
+	for {
+		now := time.Now().UnixMilli()
+		klines, err := srv.exchange.FuturesKlinePage(context.Background(), srv.symbol, priceType, futuresInterval, from, now, futuresPageLimit)
+		if err != nil {
+			return err
+		}
+		for _, kline := range klines {
+			srv.pushKline(container, kline)
+		}
+		if len(klines) < futuresPageLimit {
+			return nil
+		}
+		from = klines[len(klines)-1].OpenTime + intervalMs[futuresInterval]
+		time.Sleep(30 * time.Millisecond) // avoid reach request rate limit
+	}
+}
+
+// pollKline refreshes the kline in progress and appends the ones closed since, from the tail on.
+func (srv *FuturesService) pollKline(priceType PriceType, start int64) {
+	ticker := time.NewTicker(futuresPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-srv.stopCh:
+			return
+		}
+		from := start
+		if tail, err := srv.klines[priceType].Tail(); err == nil {
+			from = tail.OpenTime
+		}
+		if err := srv.requestKline(priceType, from); err != nil {
+			log.Errorf("Fail to retrieve futures klines of %s: %s", srv.symbol, err.Error())
+		}
+	}
+}
+
+// pushKline appends a kline or replaces the stored version of it while that one is still in progress,
+// the oldest klines beyond length are dropped.
+func (srv *FuturesService) pushKline(container *linkedlist.IndexLinkedList[Kline], kline *Kline) {
+	if stored, err := container.Get(kline.OpenTime); err == nil {
+		if !stored.IsFinal {
+			container.Update(kline.OpenTime, *kline)
+		}
+		return
+	}
+	if tail, err := container.Tail(); err == nil && kline.OpenTime <= tail.OpenTime {
+		return
+	}
+	if err := container.PushBack(kline.OpenTime, *kline); err != nil {
+		log.Errorf("Fail to push back futures kline %+v", kline)
+		return
+	}
+	for container.Size() > srv.length {
+		if _, err := container.PopFront(); err != nil {
+			log.Errorf("fail to pop futures kline %s", err.Error())
+			break
+		}
+	}
+}
+
+// requestFunding appends the funding rates settled after the tail, the latest ones when there is none.
+func (srv *FuturesService) requestFunding() error {
+	var start int64
+	if tail, err := srv.funding.Tail(); err == nil {
+		start = tail.FundingTime + 1
+	}
+	end := int64(0)
+	if start != 0 {
+		end = time.Now().UnixMilli()
+	}
+	rates, err := srv.exchange.FundingRatePage(context.Background(), srv.symbol, start, end, fundingLength)
+	if err != nil {
+		return err
+	}
+	for _, rate := range rates {
+		if tail, err := srv.funding.Tail(); err == nil && rate.FundingTime <= tail.FundingTime {
+			continue
+		}
+		if err := srv.funding.PushBack(rate.FundingTime, *rate); err != nil {
+			log.Errorf("Fail to push back funding rate %+v", rate)
+		}
+	}
+	for srv.funding.Size() > fundingLength {
+		if _, err := srv.funding.PopFront(); err != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (srv *FuturesService) pollFunding() {
+	ticker := time.NewTicker(fundingPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-srv.stopCh:
+			return
+		}
+		if err := srv.requestFunding(); err != nil {
+			log.Errorf("Fail to retrieve funding rates of %s: %s", srv.symbol, err.Error())
+		}
+	}
+}
+
+func (srv *FuturesService) subscribeMarkPrice() {
+	var wsMarkPriceHandler = func(markPrice *MarkPriceUpdate) {
+		srv.pushMarkPrice(markPrice)
+	}
+	var errHandler = func(err error) {
+		log.Errorf("handle error of wsMarkPrice %s", err.Error())
+	}
+	srv.serve(func() (doneC, stopC chan struct{}, err error) {
+		return srv.exchange.MarkPriceStream(srv.symbol, wsMarkPriceHandler, errHandler)
+	})
+}
+
+func (srv *FuturesService) pushMarkPrice(markPrice *MarkPriceUpdate) {
+	srv.markPrice.Store(markPrice)
+	srv.publish(markPrice)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// fakeFuturesExchange serves 1m klines of every price type up to the current minute,
+// priced 1, 2 and 3 for the contract, mark and index price, and a funding rate every 8 hours.
+type fakeFuturesExchange struct {
+	now int64
+}
+
+func (ex *fakeFuturesExchange) FuturesKlinePage(ctx context.Context, symbol string, priceType PriceType, interval string, startTime, endTime int64, limit int) ([]*Kline, error) {
+	klines := []*Kline{}
+	for openTime := (startTime + 59_999) / 60_000 * 60_000; openTime <= endTime && openTime <= ex.now && len(klines) < limit; openTime += 60_000 {
+		price := float64(priceType) + 1
+		klines = append(klines, &Kline{
+			OpenTime:  openTime,
+			Open:      price,
+			High:      price,
+			Low:       price,
+			Close:     price,
+			Volume:    1,
+			CloseTime: openTime + 59_999,
+			IsFinal:   openTime+59_999 < ex.now,
+		})
+	}
+	return klines, nil
+}
+
+func (ex *fakeFuturesExchange) FundingRatePage(ctx context.Context, symbol string, startTime, endTime int64, limit int) ([]*FundingRate, error) {
+	rates := []*FundingRate{}
+	for fundingTime := int64(0); fundingTime <= ex.now; fundingTime += 8 * 3_600_000 {
+		if fundingTime >= startTime && (endTime == 0 || fundingTime <= endTime) {
+			rates = append(rates, &FundingRate{FundingTime: fundingTime, Rate: 0.0001})
+		}
+	}
+	if len(rates) > limit {
+		rates = rates[len(rates)-limit:]
+	}
+	return rates, nil
+}
+
+func (ex *fakeFuturesExchange) MarkPriceStream(symbol string, handler func(*MarkPriceUpdate), errHandler func(error)) (doneC, stopC chan struct{}, err error) {
+	return make(chan struct{}), make(chan struct{}), nil
+}
+
+func TestFuturesServiceQuery(t *testing.T) {
+	ex := &fakeFuturesExchange{now: time.Now().UnixMilli()}
+	srv := NewFuturesService("BTCUSDT", 1500, ex, SubscriberOptions{})
+	if err := srv.Run(); err != nil {
+		t.Fatalf("Error running service: %v", err)
+	}
+	for priceType, container := range srv.klines {
+		if container.Size() != 1500 {
+			t.Errorf("Expected 1500 klines of price type %d, got %d", priceType, container.Size())
+		}
+	}
+	head, _ := srv.klines[MarkPrice].Head()
+	bars := []*Kline{}
+	err := srv.Query(MarkPrice, head.OpenTime, ex.now, "1h", func(bar *Kline) error {
+		bars = append(bars, bar)
+		return nil
+	})
+	if err != nil || len(bars) < 23 {
+		t.Fatalf("Expected about 24 hourly bars, got %d %v", len(bars), err)
+	}
+	for _, bar := range bars {
+		if bar.OpenTime%3_600_000 != 0 || bar.Close != 2 || bar.Volume != 60 || !bar.IsFinal {
+			t.Errorf("Unexpected hourly mark price bar %+v", bar)
+		}
+	}
+	// An open end reads up to the latest kline
+	n := 0
+	if err := srv.Query(MarkPrice, head.OpenTime, math.MaxInt64, "1h", func(*Kline) error { n++; return nil }); err != nil || n != len(bars) {
+		t.Errorf("Expected %d bars up to the latest kline, got %d %v", len(bars), n, err)
+	}
+	if err := srv.Query(IndexPrice, head.OpenTime, ex.now, "1s", func(*Kline) error { return nil }); err != errIntervalNotSupport {
+		t.Errorf("Expected errIntervalNotSupport, got %v", err)
+	}
+
+	rates := []*FundingRate{}
+	srv.QueryFunding(ex.now-24*3_600_000, ex.now, func(rate *FundingRate) error {
+		rates = append(rates, rate)
+		return nil
+	})
+	if len(rates) != 3 {
+		t.Errorf("Expected 3 funding rates within a day, got %d", len(rates))
+	}
+	errStop := errors.New("stop")
+	n = 0
+	if err := srv.QueryFunding(ex.now-24*3_600_000, ex.now, func(*FundingRate) error { n++; return errStop }); err != errStop || n != 1 {
+		t.Errorf("Expected the funding query to stop at the first error, got %v after %d rates", err, n)
+	}
+}
+
+func TestFuturesServiceMarkPrice(t *testing.T) {
+	srv := NewFuturesService("BTCUSDT", 10, &fakeFuturesExchange{}, SubscriberOptions{})
+	if _, err := srv.MarkPrice(); err != errMarkPriceNotExist {
+		t.Errorf("Expected errMarkPriceNotExist, got %v", err)
+	}
+	markCh := make(chan float64, 1)
+	id := srv.Subscribe(func(markPrice *MarkPriceUpdate) {
+		markCh <- markPrice.MarkPrice
+	})
+	defer srv.Unsubscribe(id)
+	srv.pushMarkPrice(&MarkPriceUpdate{Time: 1, MarkPrice: 100, FundingRate: 0.0001})
+	select {
+	case markPrice := <-markCh:
+		if markPrice != 100 {
+			t.Errorf("Expected mark price 100, got %v", markPrice)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timeout waiting for mark price")
+	}
+	if latest, _ := srv.MarkPrice(); latest.MarkPrice != 100 {
+		t.Errorf("Expected latest mark price 100, got %v", latest.MarkPrice)
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	errKlineOutOfOrder = errors.New("kline is out of order")
+	errKlineIsFinal    = errors.New("kline is final")
+	errKlineNotExist   = errors.New("kline is not existed")
+)
+
+const maxGapAttempts = 10
+
+// gap is a range of missing open times between two consecutive klines in the container.
+type gap struct {
+	start    int64
+	end      int64
+	attempts int
+}
+
+func (srv *KLineService) addGap(start, end int64) {
+	srv.gapMutex.Lock()
+	defer srv.gapMutex.Unlock()
+	log.Warnf("%s missing klines from %d to %d", srv.symbol, start, end)
+	srv.gaps[start] = &gap{
+		start: start,
+		end:   end,
+	}
+}
+
+func (srv *KLineService) hasGap(start int64) bool {
+	srv.gapMutex.Lock()
+	defer srv.gapMutex.Unlock()
+	_, exists := srv.gaps[start]
+	return exists
+}
+
+func (srv *KLineService) Gaps() int64 {
+	srv.gapMutex.Lock()
+	defer srv.gapMutex.Unlock()
+	return int64(len(srv.gaps))
+}
+
+func (srv *KLineService) listGaps() []gap {
+	srv.gapMutex.Lock()
+	defer srv.gapMutex.Unlock()
+	result := make([]gap, 0, len(srv.gaps))
+	for _, g := range srv.gaps {
+		result = append(result, *g)
+	}
+	return result
+}
+
+func (srv *KLineService) backfillGaps() {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-srv.stopCh:
+			return
+		}
+		for _, g := range srv.listGaps() {
+			srv.backfillGap(g)
+		}
+	}
+}
+
+// fillGaps backfills every outstanding gap before returning, e.g. the one between a restored snapshot and now.
+// A failed gap is retried every second up to maxGapAttempts, it returns false on Stop.
+func (srv *KLineService) fillGaps() bool {
+	for srv.Gaps() > 0 {
+		for _, g := range srv.listGaps() {
+			srv.backfillGap(g)
+			if !srv.sleep(30 * time.Millisecond) { // avoid reach request rate limit
+				return false
+			}
+		}
+		if srv.Gaps() > 0 && !srv.sleep(time.Second) {
+			return false
+		}
+	}
+	return true
+}
+
+func (srv *KLineService) backfillGap(g gap) {
+	step := intervalMs[baseInterval]
+	prev := g.start - step
+	if _, err := srv.container.Get(prev); err != nil {
+		// The kline before the gap has been popped, the gap is out of the window
+		srv.resolveGap(g.start, 0)
+		return
+	}
+	klines, err := srv.exchange.KlinePage(context.Background(), srv.symbol, baseInterval, g.start, g.end, 1000)
+	if err != nil {
+		log.Errorf("Fail to backfill klines from %d to %d: %s", g.start, g.end, err.Error())
+		srv.retryGap(g.start)
+		return
+	}
+	if len(klines) == 0 {
+		log.Warnf("%s has no klines from %d to %d, give up backfill", srv.symbol, g.start, g.end)
+		srv.resolveGap(g.start, 0)
+		return
+	}
+	srv.pushMutex.Lock()
+	nSpliced := 0
+	for _, kline := range klines {
+		if kline.OpenTime < g.start || kline.OpenTime > g.end {
+			continue
+		}
+		if err := srv.container.InsertAfter(prev, kline.OpenTime, *kline); err != nil {
+			log.Errorf("Fail to splice kline %d after %d: %s", kline.OpenTime, prev, err.Error())
+			break
+		}
+		prev = kline.OpenTime
+		nSpliced++
+	}
+	srv.pushMutex.Unlock()
+	if nSpliced == 0 {
+		srv.retryGap(g.start)
+		return
+	}
+	log.Infof("%s backfilled klines from %d to %d", srv.symbol, g.start, prev)
+	srv.resolveGap(g.start, prev+step)
+	srv.notify()
+}
+
+// resolveGap removes the gap starting at start, or moves its start to next when part of it is still missing.
+func (srv *KLineService) resolveGap(start, next int64) {
+	srv.gapMutex.Lock()
+	defer srv.gapMutex.Unlock()
+	g, exists := srv.gaps[start]
+	delete(srv.gaps, start)
+	if !exists || next == 0 || next > g.end {
+		return
+	}
+	g.start = next
+	srv.gaps[next] = g
+}
+
+func (srv *KLineService) retryGap(start int64) {
+	srv.gapMutex.Lock()
+	defer srv.gapMutex.Unlock()
+	g, exists := srv.gaps[start]
+	if !exists {
+		return
+	}
+	g.attempts++
+	if g.attempts >= maxGapAttempts {
+		log.Errorf("%s give up backfill from %d to %d after %d attempts", srv.symbol, g.start, g.end, g.attempts)
+		delete(srv.gaps, start)
+	}
+}
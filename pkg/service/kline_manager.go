@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	errSymbolExist    = errors.New("symbol is existed")
+	errSymbolNotExist = errors.New("symbol is not existed")
+)
+
+// KLineManager owns one KLineService per symbol so that a single process can serve many pairs.
+type KLineManager struct {
+	exchange Exchange
+	subOpts  SubscriberOptions
+	services map[string]*KLineService
+	mutex    sync.RWMutex
+}
+
+func NewKLineManager(exchange Exchange, subOpts SubscriberOptions) *KLineManager {
+	return &KLineManager{
+		exchange: exchange,
+		subOpts:  subOpts,
+		services: make(map[string]*KLineService),
+	}
+}
+
+func (m *KLineManager) Add(symbol string, length int64) (*KLineService, error) {
+	key := strings.ToUpper(symbol)
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.services[key]; exists {
+		return nil, errSymbolExist
+	}
+	srv := NewKLineService(key, length, m.exchange, m.subOpts)
+	m.services[key] = srv
+	return srv, nil
+}
+
+func (m *KLineManager) Get(symbol string) (*KLineService, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	srv, exists := m.services[strings.ToUpper(symbol)]
+	if !exists {
+		return nil, errSymbolNotExist
+	}
+	return srv, nil
+}
+
+func (m *KLineManager) Symbols() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	result := make([]string, 0, len(m.services))
+	for symbol := range m.services {
+		result = append(result, symbol)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (m *KLineManager) Run() {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for symbol, srv := range m.services {
+		log.Infof("Start kline service of %s", symbol)
+		go srv.Run()
+	}
+}
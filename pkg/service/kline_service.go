@@ -2,74 +2,139 @@ package service
 
 import (
 	"context"
-	"strings"
+	"errors"
 	"sync"
 	"time"
 
 	"github.com/BullionBear/crypto-feed/pkg/linkedlist"
-	"github.com/adshao/go-binance/v2"
 	log "github.com/sirupsen/logrus"
 )
 
+var errServiceStopped = errors.New("service is stopped")
+
+// maxHistoryAttempts bounds the failed requests in a row before the history is left short of length.
+const maxHistoryAttempts = 10
+
 type KLineService struct {
 	symbol string
 	length int64
 	// Container
 	container linkedlist.IndexLinkedList[Kline]
 	// Dependencies
-	client binance.Client
+	exchange Exchange
 	// Subscriber
 	id          int64
-	subscribers map[int64]func(*Kline)
+	subscribers map[int64]*klineSubscriber
+	subOpts     SubscriberOptions
 	// Dynamic varaible
 	currentTime int64
+	isPublished bool // Whether the kline at currentTime has been published
 	status      Status
 	errCh       chan struct{}
+	stopCh      chan struct{}
+	stopOnce    sync.Once
 	// pipeline control
-	mutex   sync.RWMutex
-	eventCh chan struct{}
+	mutex     sync.RWMutex
+	pushMutex sync.Mutex
+	eventCh   chan struct{}
+	// gap control
+	gaps     map[int64]*gap
+	gapMutex sync.Mutex
+	// persistence, nil unless EnableSnapshot is called
+	store *snapshotStore
 	// init control
-	isSetup bool
+	isSetup  bool
+	restored bool // Whether the container was restored from a snapshot
 }
 
-func NewKLineService(symbol string, length int64) *KLineService {
+func NewKLineService(symbol string, length int64, exchange Exchange, subOpts SubscriberOptions) *KLineService {
 	return &KLineService{
 		symbol:      symbol,
 		length:      length,
 		container:   *linkedlist.NewIndexedLinkedList[Kline](),
-		client:      *binance.NewClient("", ""),
+		exchange:    exchange,
 		id:          0,
-		subscribers: make(map[int64]func(*Kline)),
+		subscribers: make(map[int64]*klineSubscriber),
+		subOpts:     subOpts,
 		currentTime: 0,
 		status:      StatusCreated,
 		errCh:       make(chan struct{}),
+		stopCh:      make(chan struct{}),
 		eventCh:     make(chan struct{}, 1),
+		gaps:        make(map[int64]*gap),
 		isSetup:     false,
 	}
 }
 
 func (srv *KLineService) Run() error {
 	srv.status = StatusInitializing
+	if srv.store != nil {
+		srv.restoreKline()
+	}
 	setupCh := make(chan struct{})
 	go srv.publishKline(setupCh)
 	go srv.requestCurrentKline()
-	<-setupCh
+	select {
+	case <-setupCh:
+	case <-srv.stopCh:
+		return errServiceStopped
+	}
 	log.Info("Received First Kline")
-	go srv.requestHistoricalKline(setupCh)
-	<-setupCh
+	if !srv.requestHistoricalKline() {
+		return errServiceStopped
+	}
 	log.Info("Finish retrieve historical klines")
+	if !srv.fillGaps() {
+		return errServiceStopped
+	}
 	go srv.popHistoricalKline()
+	go srv.backfillGaps()
 	go srv.subscribeCurrentKline()
+	if srv.store != nil {
+		go srv.snapshotKline()
+	}
 	srv.status = StatusRunning
 	go func() {
-		for range srv.errCh {
-			srv.status = StatusError
-			log.Errorf("some goroutine dead, need to check")
+		for {
+			select {
+			case <-srv.errCh:
+				srv.status = StatusError
+				log.Errorf("some goroutine dead, need to check")
+			case <-srv.stopCh:
+				return
+			}
 		}
 	}()
 	return nil
 }
 
+// Stop ends the goroutines started by Run and closes the websocket, subscribers stay registered.
+func (srv *KLineService) Stop() {
+	srv.stopOnce.Do(func() {
+		close(srv.stopCh)
+	})
+}
+
+// sleep waits for d and reports false if the service is stopped in the meantime.
+func (srv *KLineService) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-srv.stopCh:
+		return false
+	}
+}
+
+// notify wakes up publishKline, unless the service is stopped.
+func (srv *KLineService) notify() {
+	select {
+	case srv.eventCh <- struct{}{}:
+	case <-srv.stopCh:
+	}
+}
+
 func (srv *KLineService) Symbol() string {
 	return srv.symbol
 }
@@ -94,18 +159,120 @@ func (srv *KLineService) Status() Status {
 	return srv.status
 }
 
+// Subscribe registers a handler receiving each kline once it is closed.
 func (srv *KLineService) Subscribe(handler func(event *Kline)) int64 {
+	return srv.addSubscriber(newSubscriber(handler, srv.subOpts), false).id
+}
+
+// SubscribeUpdate registers a handler receiving every intra-bar update of the latest kline,
+// including its final version.
+func (srv *KLineService) SubscribeUpdate(handler func(event *Kline)) int64 {
+	return srv.addSubscriber(newSubscriber(handler, srv.subOpts), true).id
+}
+
+// SubscribeStateful registers a handler keeping state across klines, e.g. an aggregator or the recorder.
+// A dropped kline would leave that state silently wrong, so its queue disconnects on overflow whatever
+// the policy, the owner resumes through SubscribeFrom.
+func (srv *KLineService) SubscribeStateful(handler func(event *Kline)) int64 {
+	return srv.addSubscriber(newSubscriber(handler, srv.statefulOptions()), false).id
+}
+
+func (srv *KLineService) statefulOptions() SubscriberOptions {
+	opts := srv.subOpts
+	opts.Overflow = OverflowDisconnect
+	return opts
+}
+
+// klineSubscriber is a subscriber of either closed klines or every intra-bar update.
+type klineSubscriber struct {
+	*subscriber[Kline]
+	updates bool
+}
+
+func (srv *KLineService) addSubscriber(sub *subscriber[Kline], updates bool) *subscriber[Kline] {
 	srv.mutex.Lock()
 	defer srv.mutex.Unlock()
-	id := srv.id
-	srv.subscribers[id] = handler
+	sub.id = srv.id
+	srv.subscribers[sub.id] = &klineSubscriber{subscriber: sub, updates: updates}
 	srv.id++
-	return id
+	go sub.run()
+	return sub
+}
+
+// Kicked returns a channel receiving the reason when the service drops the subscriber,
+// e.g. because its queue overflowed under the disconnect policy or an admin disconnected it.
+func (srv *KLineService) Kicked(subscriberID int64) (<-chan error, error) {
+	sub, err := srv.getSubscriber(subscriberID)
+	if err != nil {
+		return nil, err
+	}
+	return sub.errCh, nil
+}
+
+// SubscribeInterval subscribes to klines rolled up into the given interval,
+// the handler receives each bar once its bucket closes.
+func (srv *KLineService) SubscribeInterval(interval string, handler func(event *Kline)) (int64, error) {
+	agg, err := NewKlineAggregator(interval)
+	if err != nil {
+		return 0, err
+	}
+	if agg.width == intervalMs[baseInterval] {
+		return srv.Subscribe(handler), nil
+	}
+	return srv.SubscribeStateful(func(kline *Kline) {
+		if agg.isEmpty() {
+			srv.seedAggregator(agg, kline)
+		}
+		for _, bar := range agg.Add(kline) {
+			handler(bar)
+		}
+	}), nil
+}
+
+// DescribeSubscriber attaches the peer and client name to a subscriber for GetSubscriber.
+func (srv *KLineService) DescribeSubscriber(subscriberID int64, meta SubscriberMeta) error {
+	sub, err := srv.getSubscriber(subscriberID)
+	if err != nil {
+		return err
+	}
+	sub.meta.Store(&meta)
+	return nil
+}
+
+// MarkSent records that the owner of a subscriber delivered one more kline to its client.
+func (srv *KLineService) MarkSent(subscriberID int64) {
+	if sub, err := srv.getSubscriber(subscriberID); err == nil {
+		sub.markSent()
+	}
+}
+
+// Disconnect forcibly drops a subscriber, its owner is told through Kicked.
+func (srv *KLineService) Disconnect(subscriberID int64) error {
+	sub, err := srv.getSubscriber(subscriberID)
+	if err != nil {
+		return err
+	}
+	log.Infof("disconnect subscriber %d of %s", subscriberID, srv.symbol)
+	sub.kick(ErrDisconnected)
+	return nil
+}
+
+func (srv *KLineService) getSubscriber(subscriberID int64) (*subscriber[Kline], error) {
+	srv.mutex.RLock()
+	defer srv.mutex.RUnlock()
+	sub, exists := srv.subscribers[subscriberID]
+	if !exists {
+		return nil, errSubscriberNotExist
+	}
+	return sub.subscriber, nil
 }
 
 func (srv *KLineService) Unsubscribe(subscriberID int64) error {
 	srv.mutex.Lock()
 	defer srv.mutex.Unlock()
+	if sub, exists := srv.subscribers[subscriberID]; exists {
+		sub.close()
+	}
 	delete(srv.subscribers, subscriberID)
 	log.Infof("current number of subscribers %d", len(srv.subscribers))
 	return nil
@@ -121,7 +288,18 @@ func (srv *KLineService) ListSubsriber() []int64 {
 	return result
 }
 
-func (srv *KLineService) Query(start int64, end int64, handler func(event *Kline)) error {
+func (srv *KLineService) SubscriberInfos() []SubscriberInfo {
+	result := []SubscriberInfo{}
+	srv.mutex.RLock()
+	defer srv.mutex.RUnlock()
+	for _, sub := range srv.subscribers {
+		result = append(result, sub.info())
+	}
+	return result
+}
+
+// Query passes the klines opened in [start, end], an error of the handler stops it and is returned.
+func (srv *KLineService) Query(start int64, end int64, handler func(event *Kline) error) error {
 	key := start
 	for {
 		kline, err := srv.container.Get(key)
@@ -129,7 +307,9 @@ func (srv *KLineService) Query(start int64, end int64, handler func(event *Kline
 			log.Errorf("Failed to query key %d: %s", key, err.Error())
 			return err
 		}
-		handler(&kline)
+		if err := handler(&kline); err != nil {
+			return err
+		}
 
 		if key == end {
 			break
@@ -143,15 +323,71 @@ func (srv *KLineService) Query(start int64, end int64, handler func(event *Kline
 	return nil
 }
 
-func (srv *KLineService) subscribeCurrentKline() {
-	log.Info("start subscribe current kline")
-	var wsKlineHandler = func(event *binance.WsKlineEvent) {
-		kline, err := convertFromWsKline(&event.Kline)
+// QueryInterval rolls the container up into the given interval and only emits buckets fully covered by it.
+func (srv *KLineService) QueryInterval(start int64, end int64, interval string, handler func(event *Kline) error) error {
+	agg, err := NewKlineAggregator(interval)
+	if err != nil {
+		return err
+	}
+	if agg.width == intervalMs[baseInterval] {
+		return srv.Query(start, end, handler)
+	}
+	head, err := srv.Head()
+	if err != nil {
+		return err
+	}
+	tail, err := srv.Tail()
+	if err != nil {
+		return err
+	}
+	first := agg.bucketStart(start)
+	if first < head.OpenTime {
+		first = agg.bucketStart(head.OpenTime-1) + agg.width
+	}
+	last := agg.bucketStart(end) + agg.width - intervalMs[baseInterval]
+	if last > tail.OpenTime {
+		last = agg.bucketStart(tail.OpenTime+intervalMs[baseInterval]) - intervalMs[baseInterval]
+	}
+	if last < first {
+		return nil
+	}
+	return srv.Query(first, last, func(kline *Kline) error {
+		for _, bar := range agg.Add(kline) {
+			if err := handler(bar); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// seedAggregator feeds the klines preceding the given one within its bucket,
+// so that a subscriber joining mid-bucket still gets a complete first bar.
+func (srv *KLineService) seedAggregator(agg *KlineAggregator, kline *Kline) {
+	start := agg.bucketStart(kline.OpenTime)
+	keys := []int64{}
+	for key := kline.OpenTime; ; {
+		prev, err := srv.container.Prev(key)
+		if err != nil || prev < start {
+			break
+		}
+		keys = append(keys, prev)
+		key = prev
+	}
+	for i := len(keys) - 1; i >= 0; i-- {
+		prevKline, err := srv.container.Get(keys[i])
 		if err != nil {
-			log.Errorf("fail to convert wsKline %s", err.Error())
+			continue
 		}
+		agg.Add(&prevKline)
+	}
+}
+
+func (srv *KLineService) subscribeCurrentKline() {
+	log.Info("start subscribe current kline")
+	var wsKlineHandler = func(kline *Kline) {
 		srv.pushBack(kline)
-		srv.eventCh <- struct{}{}
+		srv.notify()
 	}
 	var errHandler = func(err error) {
 		log.Errorf("handle error of wsKline %s", err.Error())
@@ -159,127 +395,180 @@ func (srv *KLineService) subscribeCurrentKline() {
 	reconnectCh := make(chan struct{}, 1)
 	reconnectCh <- struct{}{}
 	for range reconnectCh {
-		doneC, _, err := binance.WsKlineServe(srv.symbol, "1s", wsKlineHandler, errHandler)
+		doneC, stopC, err := srv.exchange.KlineStream(srv.symbol, baseInterval, wsKlineHandler, errHandler)
 		if err != nil {
 			log.Errorf("fail to create ws channel: %s", err.Error())
+			if !srv.sleep(5 * time.Second) {
+				return
+			}
+			reconnectCh <- struct{}{}
+			continue
+		}
+		if !srv.sleep(5 * time.Second) {
+			close(stopC)
+			<-doneC
+			return
+		}
+		select {
+		case <-doneC:
+		case <-srv.stopCh:
+			close(stopC)
+			<-doneC
+			return
 		}
-		time.Sleep(5 * time.Second)
-		<-doneC
 		reconnectCh <- struct{}{}
 	}
-	srv.errCh <- struct{}{}
 }
 
 func (srv *KLineService) requestCurrentKline() {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
-	ksrv := srv.client.NewKlinesService()
-	ksrv.Symbol(strings.ToUpper(srv.symbol))
-	ksrv.Limit(5) // The latest recent 5
-	ksrv.Interval("1s")
-	for range ticker.C {
+	limit := 5 // The latest recent 5
+	for {
+		select {
+		case <-ticker.C:
+		case <-srv.stopCh:
+			return
+		}
 		// Perform the request
+		var klines []*Kline
+		var err error
 		if srv.isSetup {
-			startTime, err := srv.container.Tail()
-			if err != nil {
+			var startTime Kline
+			if startTime, err = srv.container.Tail(); err != nil {
 				log.Errorf("Fail to get latest data")
 				continue
 			}
-			ksrv.StartTime(startTime.CloseTime)
-			ksrv.EndTime(time.Now().UTC().UnixMilli())
+			klines, err = srv.exchange.KlinePage(context.Background(), srv.symbol, baseInterval, startTime.CloseTime, time.Now().UTC().UnixMilli(), limit)
+		} else {
+			klines, err = srv.exchange.RecentKlines(context.Background(), srv.symbol, baseInterval, limit)
 		}
-
-		bKlines, err := ksrv.Do(context.Background())
 		if err != nil {
 			log.Errorf("Fail to retrieve kline: %+v", err)
 			continue
 		}
 
-		for _, bKline := range bKlines {
-			kline, err := convertFromKline(bKline)
-			if err != nil {
-				log.Errorf("Fail to convert kline: %+v", bKline)
-				continue
-			}
+		for _, kline := range klines {
 			if err := srv.pushBack(kline); err == nil {
-				srv.eventCh <- struct{}{}
+				srv.notify()
 				continue
 			}
 		}
 	}
-	srv.errCh <- struct{}{}
 }
 
 func (srv *KLineService) publishKline(setupCh chan<- struct{}) {
-	for range srv.eventCh {
+	for {
+		select {
+		case <-srv.eventCh:
+		case <-srv.stopCh:
+			return
+		}
 		if !srv.isSetup {
-			currentTime, err := srv.container.HeadKey(0) // Initialize running Key
-			if err != nil {
-				log.Warnf("unable to retrieve currentTime")
+			if !srv.restored {
+				currentTime, err := srv.container.HeadKey(0) // Initialize running Key
+				if err != nil {
+					log.Warnf("unable to retrieve currentTime")
+				}
+				srv.currentTime = currentTime
+				srv.isPublished = false
 			}
-			srv.currentTime = currentTime
 			srv.isSetup = true
 			setupCh <- struct{}{}
 		}
 		for {
 			nextTime, err := srv.container.Next(srv.currentTime)
 			if err != nil {
+				// currentTime is the tail, it is published as soon as it is final
+				kline, err := srv.container.Get(srv.currentTime)
+				if err == nil && kline.IsFinal && !srv.isPublished {
+					srv.publish(&kline, false)
+					srv.isPublished = true
+				}
 				break
 			}
-			kline, _ := srv.container.Get(srv.currentTime)
-			srv.mutex.RLock()
-			for _, subscriber := range srv.subscribers {
-				subscriber(&kline)
+			if nextTime != srv.currentTime+intervalMs[baseInterval] && srv.hasGap(srv.currentTime+intervalMs[baseInterval]) {
+				break // Hold back until the gap after currentTime is backfilled
+			}
+			if !srv.isPublished {
+				kline, _ := srv.container.Get(srv.currentTime)
+				srv.publish(&kline, false)
 			}
-			srv.mutex.RUnlock()
 			srv.currentTime = nextTime
+			srv.isPublished = false
 		}
 	}
-	srv.errCh <- struct{}{}
 }
 
-func (srv *KLineService) requestHistoricalKline(setupCh chan<- struct{}) {
+// publish hands a kline to either the closed bar or the intra-bar update subscribers,
+// closed klines are also appended to the write-ahead log.
+func (srv *KLineService) publish(kline *Kline, updates bool) {
+	if srv.store != nil && !updates {
+		srv.store.appendWal(kline)
+	}
+	srv.mutex.RLock()
+	defer srv.mutex.RUnlock()
+	for _, sub := range srv.subscribers {
+		if sub.updates == updates {
+			sub.offer(kline)
+		}
+	}
+}
 
-	ksrv := srv.client.NewKlinesService()
+// requestHistoricalKline pages backwards from the head until the container holds length klines,
+// or the exchange has nothing older or keeps failing. It returns false on Stop.
+func (srv *KLineService) requestHistoricalKline() bool {
 	limit := 1000
-	ksrv.Symbol(strings.ToUpper(srv.symbol))
-	ksrv.Interval("1s")
-	ksrv.Limit(limit)
+	attempts := 0
 	for size := srv.container.Size(); size < srv.length; size = srv.container.Size() {
-		// log.Infof("Current Size: %d", size)
 		startKline, err := srv.container.Head()
 		if err != nil {
 			log.Errorf("fail get head kline %s", err.Error())
+			return true
 		}
 		endTime := startKline.OpenTime
 		startTime := endTime - int64(limit*1000) // rollback 500 seconds
-		ksrv.StartTime(startTime - 1)
-		ksrv.EndTime(endTime)
-		bklines, err := ksrv.Do(context.Background())
+		klines, err := srv.exchange.KlinePage(context.Background(), srv.symbol, baseInterval, startTime-1, endTime, limit)
 		if err != nil {
+			attempts++
 			log.Errorf("Fail to retrieve historical klines %s", err.Error())
-		}
-		for i := len(bklines) - 1; i >= 0; i-- {
-			bkline := bklines[i]
-			kline, err := convertFromKline(bkline)
-			if err != nil {
-				log.Errorf("Fail to convert klines %s", err.Error())
-				continue
+			if attempts >= maxHistoryAttempts {
+				log.Errorf("%s give up historical klines after %d attempts, keep %d klines", srv.symbol, attempts, size)
+				return true
 			}
+			if !srv.sleep(time.Second) {
+				return false
+			}
+			continue
+		}
+		attempts = 0
+		if len(klines) == 0 {
+			log.Warnf("%s has no klines before %d, keep %d klines", srv.symbol, endTime, size)
+			return true
+		}
+		for i := len(klines) - 1; i >= 0; i-- {
+			kline := klines[i]
 			if err := srv.pushFront(kline); err != nil {
 				log.Errorf("Fail to push front kline %+v", kline)
 			}
 		}
-		time.Sleep(30 * time.Millisecond) // avoid reach request rate limit
+		if !srv.sleep(30 * time.Millisecond) { // avoid reach request rate limit
+			return false
+		}
 	}
-	setupCh <- struct{}{}
+	return true
 }
 
 func (srv *KLineService) popHistoricalKline() {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for {
+		select {
+		case <-ticker.C:
+		case <-srv.stopCh:
+			return
+		}
 		nPop := srv.Size() - srv.length
 		if nPop <= 0 {
 			continue
@@ -291,15 +580,51 @@ func (srv *KLineService) popHistoricalKline() {
 			}
 		}
 	}
-	srv.errCh <- struct{}{}
 }
 
+// pushBack appends a kline, or replaces the stored version of it while that one is still in progress.
 func (srv *KLineService) pushBack(kline *Kline) error {
+	if err := srv.appendKline(kline); err != nil {
+		return err
+	}
+	srv.publish(kline, true)
+	return nil
+}
+
+func (srv *KLineService) appendKline(kline *Kline) error {
+	srv.pushMutex.Lock()
+	defer srv.pushMutex.Unlock()
+	step := intervalMs[baseInterval]
+	if stored, err := srv.container.Get(kline.OpenTime); err == nil {
+		if stored.IsFinal {
+			return errKlineIsFinal
+		}
+		return srv.container.Update(kline.OpenTime, *kline)
+	}
+	if tail, err := srv.container.Tail(); err == nil {
+		if kline.OpenTime <= tail.OpenTime {
+			return errKlineOutOfOrder
+		}
+		if kline.OpenTime > tail.OpenTime+step {
+			srv.addGap(tail.OpenTime+step, kline.OpenTime-step)
+		}
+	}
 	closeTime := kline.OpenTime
 	return srv.container.PushBack(closeTime, *kline)
 }
 
 func (srv *KLineService) pushFront(kline *Kline) error {
+	srv.pushMutex.Lock()
+	defer srv.pushMutex.Unlock()
+	step := intervalMs[baseInterval]
+	if head, err := srv.container.Head(); err == nil {
+		if kline.OpenTime >= head.OpenTime {
+			return errKlineOutOfOrder
+		}
+		if kline.OpenTime < head.OpenTime-step {
+			srv.addGap(kline.OpenTime+step, head.OpenTime-step)
+		}
+	}
 	closeTime := kline.OpenTime
 	return srv.container.PushFront(closeTime, *kline)
 }
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+const fakeNow = int64(1_700_000_000_000)
+
+// fakeExchange serves deterministic 1s klines up to fakeNow.
+type fakeExchange struct{}
+
+func (ex *fakeExchange) KlinePage(ctx context.Context, symbol, interval string, startTime, endTime int64, limit int) ([]*Kline, error) {
+	klines := []*Kline{}
+	for openTime := (startTime + 999) / 1000 * 1000; openTime <= endTime && openTime <= fakeNow && len(klines) < limit; openTime += 1000 {
+		klines = append(klines, newSecondKline(openTime, float64(openTime/1000%1000)))
+	}
+	return klines, nil
+}
+
+func (ex *fakeExchange) RecentKlines(ctx context.Context, symbol, interval string, limit int) ([]*Kline, error) {
+	return ex.KlinePage(ctx, symbol, interval, fakeNow-int64(limit-1)*1000, fakeNow, limit)
+}
+
+func (ex *fakeExchange) KlineStream(symbol, interval string, handler func(*Kline), errHandler func(error)) (doneC, stopC chan struct{}, err error) {
+	return make(chan struct{}), make(chan struct{}), nil
+}
+
+func TestKLineServiceBackfill(t *testing.T) {
+	srv := NewKLineService("BTCUSDT", 2500, &fakeExchange{}, SubscriberOptions{})
+	if err := srv.Run(); err != nil {
+		t.Fatalf("Error running service: %v", err)
+	}
+	if srv.Size() < 2500 {
+		t.Fatalf("Expected at least 2500 klines, got %d", srv.Size())
+	}
+	head, _ := srv.Head()
+	tail, _ := srv.Tail()
+	if tail.OpenTime != fakeNow {
+		t.Errorf("Expected tail at %d, got %d", fakeNow, tail.OpenTime)
+	}
+	expected := head.OpenTime
+	err := srv.Query(head.OpenTime, tail.OpenTime, func(kline *Kline) error {
+		if kline.OpenTime != expected {
+			t.Errorf("Expected kline at %d, got %d", expected, kline.OpenTime)
+		}
+		expected += 1000
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Error querying klines: %v", err)
+	}
+}
+
+// failingHistoryExchange serves the recent klines but fails every history page.
+type failingHistoryExchange struct {
+	fakeExchange
+}
+
+func (ex *failingHistoryExchange) KlinePage(ctx context.Context, symbol, interval string, startTime, endTime int64, limit int) ([]*Kline, error) {
+	return nil, errors.New("history is unavailable")
+}
+
+func TestKLineServiceStopDuringHistory(t *testing.T) {
+	srv := NewKLineService("BTCUSDT", 2500, &failingHistoryExchange{}, SubscriberOptions{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Run()
+	}()
+	time.Sleep(1500 * time.Millisecond) // The first klines arrive after a second, then history fails
+	srv.Stop()
+	select {
+	case err := <-errCh:
+		if err != errServiceStopped {
+			t.Errorf("Expected errServiceStopped, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Expected Run to return once stopped")
+	}
+}
+
+func TestKLineServiceBackfillGap(t *testing.T) {
+	srv := NewKLineService("BTCUSDT", 100, &fakeExchange{}, SubscriberOptions{})
+	start := fakeNow - 10_000
+	srv.pushBack(newSecondKline(start, 1))
+	srv.pushBack(newSecondKline(start+5000, 1))
+	if err := srv.pushBack(newSecondKline(start+3000, 1)); err != errKlineOutOfOrder {
+		t.Errorf("Expected errKlineOutOfOrder, got %v", err)
+	}
+	if srv.Gaps() != 1 {
+		t.Fatalf("Expected 1 gap, got %d", srv.Gaps())
+	}
+	srv.backfillGap(srv.listGaps()[0])
+	if srv.Gaps() != 0 {
+		t.Errorf("Expected gap to be resolved, got %d", srv.Gaps())
+	}
+	expected := start
+	err := srv.Query(start, start+5000, func(kline *Kline) error {
+		if kline.OpenTime != expected {
+			t.Errorf("Expected kline at %d, got %d", expected, kline.OpenTime)
+		}
+		expected += 1000
+		return nil
+	})
+	if err != nil || expected != start+6000 {
+		t.Errorf("Expected a contiguous series, stopped at %d: %v", expected, err)
+	}
+}
+
+func TestKLineServiceReplacesKlineInProgress(t *testing.T) {
+	srv := NewKLineService("BTCUSDT", 100, &fakeExchange{}, SubscriberOptions{})
+	updates := make(chan *Kline, 10)
+	srv.SubscribeUpdate(func(kline *Kline) {
+		updates <- kline
+	})
+	partial := newSecondKline(fakeNow, 1)
+	partial.IsFinal = false
+	if err := srv.pushBack(partial); err != nil {
+		t.Fatalf("Error pushing partial kline: %v", err)
+	}
+	if err := srv.pushBack(newSecondKline(fakeNow, 2)); err != nil {
+		t.Fatalf("Error replacing partial kline: %v", err)
+	}
+	if err := srv.pushBack(newSecondKline(fakeNow, 3)); err != errKlineIsFinal {
+		t.Errorf("Expected errKlineIsFinal, got %v", err)
+	}
+	tail, _ := srv.Tail()
+	if !tail.IsFinal || tail.Close != 2 || srv.Size() != 1 {
+		t.Errorf("Expected the final kline to replace the partial one, got %+v", tail)
+	}
+	for _, isFinal := range []bool{false, true} {
+		select {
+		case kline := <-updates:
+			if kline.IsFinal != isFinal {
+				t.Errorf("Expected update with IsFinal %v, got %+v", isFinal, kline)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timeout waiting for update")
+		}
+	}
+}
+
+func TestKLineServiceSubscribeFrom(t *testing.T) {
+	srv := NewKLineService("BTCUSDT", 100, &fakeExchange{}, SubscriberOptions{})
+	start := fakeNow - 10_000
+	for openTime := start; openTime < start+10_000; openTime += 1000 {
+		srv.pushBack(newSecondKline(openTime, 1))
+	}
+	received := make(chan int64, 100)
+	id, err := srv.SubscribeFrom(start+3000, "1s", func(kline *Kline) {
+		received <- kline.OpenTime
+	})
+	if err != nil {
+		t.Fatalf("Error subscribing: %v", err)
+	}
+	// Live klines overlapping the replay must not be delivered twice
+	live := newSecondKline(start+8000, 1)
+	srv.publish(live, false)
+	next := newSecondKline(start+10_000, 1)
+	srv.pushBack(next)
+	srv.publish(next, false)
+
+	expected := start + 3000
+	for expected <= start+10_000 {
+		select {
+		case openTime := <-received:
+			if openTime != expected {
+				t.Fatalf("Expected kline at %d, got %d", expected, openTime)
+			}
+			expected += 1000
+		case <-time.After(time.Second):
+			t.Fatalf("Timeout waiting for kline at %d", expected)
+		}
+	}
+	srv.Unsubscribe(id)
+	select {
+	case openTime := <-received:
+		t.Errorf("Unexpected kline at %d", openTime)
+	default:
+	}
+}
+
+func TestKLineServiceSubscribeFromBeforeHead(t *testing.T) {
+	srv := NewKLineService("BTCUSDT", 100, &fakeExchange{}, SubscriberOptions{})
+	start := fakeNow - 10_000
+	for openTime := start; openTime < start+10_000; openTime += 1000 {
+		srv.pushBack(newSecondKline(openTime, 1))
+	}
+	if _, err := srv.SubscribeFrom(start-1000, "1s", func(kline *Kline) {}); !errors.Is(err, ErrResumeTooOld) {
+		t.Errorf("Expected ErrResumeTooOld, got %v", err)
+	}
+	received := make(chan int64, 100)
+	if _, err := srv.SubscribeSince(start-1000, "1s", func(kline *Kline) { received <- kline.OpenTime }); err != nil {
+		t.Fatalf("Error subscribing: %v", err)
+	}
+	select {
+	case openTime := <-received:
+		if openTime != start {
+			t.Errorf("Expected the warm up to start at the head %d, got %d", start, openTime)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timeout waiting for the head")
+	}
+}
+
+func TestKLineServiceAggregatingSubscriberDisconnects(t *testing.T) {
+	srv := NewKLineService("BTCUSDT", 100, &fakeExchange{}, SubscriberOptions{QueueSize: 1, Overflow: OverflowDropOldest})
+	block := make(chan struct{})
+	defer close(block)
+	id, err := srv.SubscribeInterval("1m", func(kline *Kline) { <-block })
+	if err != nil {
+		t.Fatalf("Error subscribing: %v", err)
+	}
+	kickedCh, _ := srv.Kicked(id)
+	for i := int64(0); i < 120; i++ {
+		srv.publish(newSecondKline(fakeNow+i*1000, 1), false)
+	}
+	select {
+	case err := <-kickedCh:
+		if err != errSlowConsumer {
+			t.Errorf("Expected errSlowConsumer, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected an aggregating subscriber to be disconnected instead of dropping klines")
+	}
+}
+
+func TestSubscriberOverflow(t *testing.T) {
+	for _, policy := range []OverflowPolicy{OverflowDropOldest, OverflowDropNewest, OverflowDisconnect} {
+		// The subscriber is not running, so nothing drains its queue
+		sub := newSubscriber(func(kline *Kline) {}, SubscriberOptions{QueueSize: 2, Overflow: policy})
+		for i := int64(0); i < 5; i++ {
+			sub.offer(newSecondKline(i*1000, 1))
+		}
+		switch policy {
+		case OverflowDropOldest:
+			if first := <-sub.queue; sub.dropped.Load() != 3 || first.OpenTime != 3000 {
+				t.Errorf("Expected oldest klines to be dropped, got %d dropped and head %d", sub.dropped.Load(), first.OpenTime)
+			}
+		case OverflowDropNewest:
+			if first := <-sub.queue; sub.dropped.Load() != 3 || first.OpenTime != 0 {
+				t.Errorf("Expected newest klines to be dropped, got %d dropped and head %d", sub.dropped.Load(), first.OpenTime)
+			}
+		case OverflowDisconnect:
+			if err := <-sub.errCh; err != errSlowConsumer || !sub.isClosed() {
+				t.Errorf("Expected subscriber to be kicked, got %v", err)
+			}
+		}
+	}
+}
+
+func TestKLineServiceDisconnectSubscriber(t *testing.T) {
+	srv := NewKLineService("BTCUSDT", 100, &fakeExchange{}, SubscriberOptions{})
+	id := srv.Subscribe(func(kline *Kline) {})
+	srv.DescribeSubscriber(id, SubscriberMeta{Peer: "127.0.0.1:1234", Name: "strategy"})
+	srv.MarkSent(id)
+	infos := srv.SubscriberInfos()
+	if len(infos) != 1 || infos[0].Name != "strategy" || infos[0].Peer != "127.0.0.1:1234" || infos[0].Sent != 1 || infos[0].LastSendTime == 0 {
+		t.Errorf("Unexpected subscriber infos %+v", infos)
+	}
+	kickedCh, _ := srv.Kicked(id)
+	if err := srv.Disconnect(id); err != nil {
+		t.Fatalf("Error disconnecting subscriber: %v", err)
+	}
+	if err := <-kickedCh; err != ErrDisconnected {
+		t.Errorf("Expected ErrDisconnected, got %v", err)
+	}
+	if err := srv.Disconnect(id + 1); err != errSubscriberNotExist {
+		t.Errorf("Expected errSubscriberNotExist, got %v", err)
+	}
+}
+
+func TestKLineServiceSnapshotRestore(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().UnixMilli()/1000*1000 - 60_000
+	srv := NewKLineService("BTCUSDT", 100, &fakeExchange{}, SubscriberOptions{})
+	if err := srv.EnableSnapshot(dir, time.Minute); err != nil {
+		t.Fatalf("Error enabling snapshot: %v", err)
+	}
+	for i := int64(0); i < 10; i++ {
+		srv.appendKline(newSecondKline(start+i*1000, float64(i)))
+	}
+	if err := srv.writeSnapshot(); err != nil {
+		t.Fatalf("Error writing snapshot: %v", err)
+	}
+	// Klines published after the snapshot only live in the write-ahead log
+	if err := srv.store.openWal(); err != nil {
+		t.Fatalf("Error opening wal: %v", err)
+	}
+	for i := int64(10); i < 12; i++ {
+		srv.store.appendWal(newSecondKline(start+i*1000, float64(i)))
+	}
+
+	restored := NewKLineService("BTCUSDT", 100, &fakeExchange{}, SubscriberOptions{})
+	if err := restored.EnableSnapshot(dir, time.Minute); err != nil {
+		t.Fatalf("Error enabling snapshot: %v", err)
+	}
+	restored.restoreKline()
+	if restored.Size() != 12 {
+		t.Fatalf("Expected 12 restored klines, got %d", restored.Size())
+	}
+	tail, _ := restored.Tail()
+	if tail.OpenTime != start+11_000 || tail.Close != 11 {
+		t.Errorf("Expected tail at %d, got %+v", start+11_000, tail)
+	}
+	if !restored.restored || restored.currentTime != tail.OpenTime {
+		t.Errorf("Expected publisher to resume after the restored tail, got %d", restored.currentTime)
+	}
+}
+
+func TestKlineRecordLayout(t *testing.T) {
+	kline := newSecondKline(fakeNow, 42)
+	kline.TradeNum = 7
+	record := make([]byte, klineRecordSize)
+	encodeKline(record, kline)
+	// Files written before the explicit encoding used binary.Write of the struct
+	var legacy bytes.Buffer
+	binary.Write(&legacy, binary.LittleEndian, kline)
+	if !bytes.Equal(record, legacy.Bytes()) {
+		t.Errorf("Expected the record to match version %d files", snapshotVersion)
+	}
+	if decoded := decodeKline(record); decoded != *kline {
+		t.Errorf("Expected %+v, got %+v", *kline, decoded)
+	}
+}
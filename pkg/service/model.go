@@ -1,11 +1,5 @@
 package service
 
-import (
-	"strconv"
-
-	"github.com/adshao/go-binance/v2"
-)
-
 type Status string
 
 var (
@@ -27,126 +21,75 @@ type Kline struct {
 	TradeNum                 int64   `json:"tradeNum"`
 	TakerBuyBaseAssetVolume  float64 `json:"takerBuyBaseAssetVolume"`
 	TakerBuyQuoteAssetVolume float64 `json:"takerBuyQuoteAssetVolume"`
+	IsFinal                  bool    `json:"isFinal"`
 }
 
-func convertFromKline(bKline *binance.Kline) (*Kline, error) {
-	// Helper function to convert string to float64
-	strToFloat64 := func(s string) (float64, error) {
-		return strconv.ParseFloat(s, 64)
-	}
-
-	open, err := strToFloat64(bKline.Open)
-	if err != nil {
-		return nil, err
-	}
-
-	high, err := strToFloat64(bKline.High)
-	if err != nil {
-		return nil, err
-	}
-
-	low, err := strToFloat64(bKline.Low)
-	if err != nil {
-		return nil, err
-	}
-
-	close, err := strToFloat64(bKline.Close)
-	if err != nil {
-		return nil, err
-	}
-
-	volume, err := strToFloat64(bKline.Volume)
-	if err != nil {
-		return nil, err
-	}
-
-	quoteAssetVolume, err := strToFloat64(bKline.QuoteAssetVolume)
-	if err != nil {
-		return nil, err
-	}
-
-	takerBuyBaseAssetVolume, err := strToFloat64(bKline.TakerBuyBaseAssetVolume)
-	if err != nil {
-		return nil, err
-	}
-
-	takerBuyQuoteAssetVolume, err := strToFloat64(bKline.TakerBuyQuoteAssetVolume)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Kline{
-		OpenTime:                 bKline.OpenTime,
-		Open:                     open,
-		High:                     high,
-		Low:                      low,
-		Close:                    close,
-		Volume:                   volume,
-		CloseTime:                bKline.CloseTime,
-		QuoteAssetVolume:         quoteAssetVolume,
-		TradeNum:                 bKline.TradeNum,
-		TakerBuyBaseAssetVolume:  takerBuyBaseAssetVolume,
-		TakerBuyQuoteAssetVolume: takerBuyQuoteAssetVolume,
-	}, nil
+// Trade is an aggregate trade, or an individual trade whose FirstTradeID and LastTradeID are its ID.
+type Trade struct {
+	ID           int64   `json:"id"`
+	Price        float64 `json:"price"`
+	Quantity     float64 `json:"quantity"`
+	FirstTradeID int64   `json:"firstTradeId"`
+	LastTradeID  int64   `json:"lastTradeId"`
+	Time         int64   `json:"time"`
+	IsBuyerMaker bool    `json:"isBuyerMaker"`
 }
 
-func convertFromWsKline(wsKline *binance.WsKline) (*Kline, error) {
-	// Helper function to convert string to float64
-	strToFloat64 := func(s string) (float64, error) {
-		return strconv.ParseFloat(s, 64)
-	}
-
-	open, err := strToFloat64(wsKline.Open)
-	if err != nil {
-		return nil, err
-	}
-
-	high, err := strToFloat64(wsKline.High)
-	if err != nil {
-		return nil, err
-	}
-
-	low, err := strToFloat64(wsKline.Low)
-	if err != nil {
-		return nil, err
-	}
+type PriceLevel struct {
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+}
 
-	close, err := strToFloat64(wsKline.Close)
-	if err != nil {
-		return nil, err
-	}
+// BookSnapshot is the top of an order book, bids best first and asks best first.
+type BookSnapshot struct {
+	LastUpdateID int64        `json:"lastUpdateId"`
+	Time         int64        `json:"time"` // Event time of the last update, 0 for a REST snapshot
+	Bids         []PriceLevel `json:"bids"`
+	Asks         []PriceLevel `json:"asks"`
+}
 
-	volume, err := strToFloat64(wsKline.Volume)
-	if err != nil {
-		return nil, err
-	}
+// DepthUpdate carries the levels changed by the updates FirstUpdateID to LastUpdateID,
+// a level with zero quantity is removed from the book.
+type DepthUpdate struct {
+	FirstUpdateID int64        `json:"firstUpdateId"`
+	LastUpdateID  int64        `json:"lastUpdateId"`
+	Time          int64        `json:"time"`
+	Bids          []PriceLevel `json:"bids"`
+	Asks          []PriceLevel `json:"asks"`
+}
 
-	quoteVolume, err := strToFloat64(wsKline.QuoteVolume)
-	if err != nil {
-		return nil, err
-	}
+type BookTicker struct {
+	UpdateID    int64   `json:"updateId"`
+	Time        int64   `json:"time"` // Receive time, the spot stream carries no event time
+	BidPrice    float64 `json:"bidPrice"`
+	BidQuantity float64 `json:"bidQuantity"`
+	AskPrice    float64 `json:"askPrice"`
+	AskQuantity float64 `json:"askQuantity"`
+}
 
-	activeBuyVolume, err := strToFloat64(wsKline.ActiveBuyVolume)
-	if err != nil {
-		return nil, err
-	}
+// Spread is the best bid and ask at the end of a second, aligned with the 1s kline of OpenTime.
+type Spread struct {
+	OpenTime   int64   `json:"openTime"`
+	Bid        float64 `json:"bid"`
+	Ask        float64 `json:"ask"`
+	Mid        float64 `json:"mid"`
+	Spread     float64 `json:"spread"`
+	MeanSpread float64 `json:"meanSpread"` // Mean over the updates within the second
+	Updates    int64   `json:"updates"`    // 0 when the last book ticker was carried over
+}
 
-	activeBuyQuoteVolume, err := strToFloat64(wsKline.ActiveBuyQuoteVolume)
-	if err != nil {
-		return nil, err
-	}
+type FundingRate struct {
+	FundingTime int64   `json:"fundingTime"`
+	Rate        float64 `json:"rate"`
+	MarkPrice   float64 `json:"markPrice"`
+}
 
-	return &Kline{
-		OpenTime:                 wsKline.StartTime,
-		Open:                     open,
-		High:                     high,
-		Low:                      low,
-		Close:                    close,
-		Volume:                   volume,
-		CloseTime:                wsKline.EndTime,
-		QuoteAssetVolume:         quoteVolume,
-		TradeNum:                 wsKline.TradeNum,
-		TakerBuyBaseAssetVolume:  activeBuyVolume,
-		TakerBuyQuoteAssetVolume: activeBuyQuoteVolume,
-	}, nil
+// MarkPriceUpdate is a mark price update of a perpetual, FundingRate is the rate of the next funding.
+type MarkPriceUpdate struct {
+	Time                 int64   `json:"time"`
+	MarkPrice            float64 `json:"markPrice"`
+	IndexPrice           float64 `json:"indexPrice"`
+	EstimatedSettlePrice float64 `json:"estimatedSettlePrice"`
+	FundingRate          float64 `json:"fundingRate"`
+	NextFundingTime      int64   `json:"nextFundingTime"`
 }
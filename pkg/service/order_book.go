@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	errDepthGap            = errors.New("depth update does not follow the book")
+	errBookNotSynced       = errors.New("order book is not synced")
+	errBookLimitNotSupport = errors.New("order book limit is not supported")
+)
+
+// depthBufferSize bounds the diffs queued while a snapshot is fetched, an overflow shows up as a gap.
+const depthBufferSize = 4096
+
+const defaultBookLimit = 1000
+
+var bookLimits = []int{5, 10, 20, 50, 100, 500, 1000, 5000}
+
+func ParseBookLimit(limit int) (int, error) {
+	if limit == 0 {
+		return defaultBookLimit, nil
+	}
+	for _, allowed := range bookLimits {
+		if limit == allowed {
+			return limit, nil
+		}
+	}
+	return 0, errBookLimitNotSupport
+}
+
+// orderBook is a local copy of an order book, levels are keyed by price.
+type orderBook struct {
+	bids         map[float64]float64
+	asks         map[float64]float64
+	lastUpdateID int64
+	time         int64
+}
+
+func newOrderBook(snapshot *BookSnapshot) *orderBook {
+	book := &orderBook{
+		bids:         make(map[float64]float64, len(snapshot.Bids)),
+		asks:         make(map[float64]float64, len(snapshot.Asks)),
+		lastUpdateID: snapshot.LastUpdateID,
+		time:         snapshot.Time,
+	}
+	setLevels(book.bids, snapshot.Bids)
+	setLevels(book.asks, snapshot.Asks)
+	return book
+}
+
+// apply merges a diff into the book. Diffs already contained in the book are skipped, the first diff
+// applied may overlap the book but must not start past it, every later one starts right after the last.
+func (book *orderBook) apply(update *DepthUpdate) error {
+	if update.LastUpdateID <= book.lastUpdateID {
+		return nil
+	}
+	if update.FirstUpdateID > book.lastUpdateID+1 {
+		return errDepthGap
+	}
+	setLevels(book.bids, update.Bids)
+	setLevels(book.asks, update.Asks)
+	book.lastUpdateID = update.LastUpdateID
+	book.time = update.Time
+	return nil
+}
+
+func (book *orderBook) top(depth int) *BookSnapshot {
+	return &BookSnapshot{
+		LastUpdateID: book.lastUpdateID,
+		Time:         book.time,
+		Bids:         topLevels(book.bids, depth, func(a, b float64) bool { return a > b }),
+		Asks:         topLevels(book.asks, depth, func(a, b float64) bool { return a < b }),
+	}
+}
+
+func setLevels(side map[float64]float64, levels []PriceLevel) {
+	for _, level := range levels {
+		if level.Quantity == 0 {
+			delete(side, level.Price)
+		} else {
+			side[level.Price] = level.Quantity
+		}
+	}
+}
+
+func topLevels(side map[float64]float64, depth int, better func(a, b float64) bool) []PriceLevel {
+	prices := make([]float64, 0, len(side))
+	for price := range side {
+		prices = append(prices, price)
+	}
+	sort.Slice(prices, func(i, j int) bool { return better(prices[i], prices[j]) })
+	if len(prices) > depth {
+		prices = prices[:depth]
+	}
+	levels := make([]PriceLevel, len(prices))
+	for i, price := range prices {
+		levels[i] = PriceLevel{Price: price, Quantity: side[price]}
+	}
+	return levels
+}
+
+// OrderBookService maintains the order book of a symbol from a REST snapshot and the websocket diffs after it,
+// the book is fetched again whenever a diff is missing.
+type OrderBookService struct {
+	symbol string
+	limit  int // Levels per side of the REST snapshot
+	// Book, nil until synced
+	book *orderBook
+	// Dependencies
+	exchange BookExchange
+	// Subscriber
+	*subscriberRegistry[DepthUpdate]
+	// Dynamic varaible
+	status   Status
+	errCh    chan struct{}
+	updateCh chan *DepthUpdate
+	// pipeline control
+	bookMutex sync.RWMutex
+}
+
+func NewOrderBookService(symbol string, limit int, exchange BookExchange, subOpts SubscriberOptions) *OrderBookService {
+	return &OrderBookService{
+		symbol:             symbol,
+		limit:              limit,
+		exchange:           exchange,
+		subscriberRegistry: newSubscriberRegistry[DepthUpdate]("order book", symbol, subOpts),
+		status:             StatusCreated,
+		errCh:              make(chan struct{}),
+		updateCh:           make(chan *DepthUpdate, depthBufferSize),
+	}
+}
+
+func (srv *OrderBookService) Run() error {
+	srv.status = StatusInitializing
+	go srv.subscribeDepth()
+	go srv.maintainBook()
+	go func() {
+		for {
+			select {
+			case <-srv.errCh:
+				srv.status = StatusError
+				log.Errorf("some goroutine dead, need to check")
+			case <-srv.stopCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (srv *OrderBookService) Symbol() string {
+	return srv.symbol
+}
+
+func (srv *OrderBookService) Status() Status {
+	return srv.status
+}
+
+// Limit is the levels per side of the snapshot, the deepest book Top can return.
+func (srv *OrderBookService) Limit() int {
+	return srv.limit
+}
+
+func (srv *OrderBookService) Top(depth int) (*BookSnapshot, error) {
+	srv.bookMutex.RLock()
+	defer srv.bookMutex.RUnlock()
+	if srv.book == nil {
+		return nil, errBookNotSynced
+	}
+	return srv.book.top(depth), nil
+}
+
+// Subscribe calls handler with the depth best levels after the book changed, at most once per throttle.
+// Changes within the throttle are sent together once it expires, so the last book is never held back.
+func (srv *OrderBookService) Subscribe(depth int, throttle time.Duration, handler func(event *BookSnapshot)) int64 {
+	var sendMutex sync.Mutex
+	var lastSend time.Time
+	pending := false
+	send := func() {
+		if top, err := srv.Top(depth); err == nil {
+			handler(top)
+		}
+		lastSend = time.Now()
+	}
+	return srv.subscribe(func(*DepthUpdate) {
+		sendMutex.Lock()
+		defer sendMutex.Unlock()
+		if pending {
+			return
+		}
+		wait := throttle - time.Since(lastSend)
+		if wait <= 0 {
+			send()
+			return
+		}
+		pending = true
+		time.AfterFunc(wait, func() {
+			sendMutex.Lock()
+			defer sendMutex.Unlock()
+			pending = false
+			send()
+		})
+	})
+}
+
+// subscribeDepth queues the diffs for maintainBook, a diff that does not fit is dropped
+// and the gap it leaves makes the book resync.
+func (srv *OrderBookService) subscribeDepth() {
+	var wsDepthHandler = func(update *DepthUpdate) {
+		select {
+		case srv.updateCh <- update:
+		default:
+			log.Warnf("depth buffer of %s is full, drop update %d", srv.symbol, update.LastUpdateID)
+		}
+	}
+	var errHandler = func(err error) {
+		log.Errorf("handle error of wsDepth %s", err.Error())
+	}
+	srv.serve(func() (doneC, stopC chan struct{}, err error) {
+		return srv.exchange.DepthStream(srv.symbol, wsDepthHandler, errHandler)
+	})
+}
+
+// maintainBook fetches a snapshot while the diffs queue up, then applies them until one is missing.
+func (srv *OrderBookService) maintainBook() {
+	for {
+		snapshot, err := srv.exchange.DepthSnapshot(context.Background(), srv.symbol, srv.limit)
+		if err != nil {
+			log.Errorf("Fail to retrieve depth snapshot of %s: %s", srv.symbol, err.Error())
+			if !srv.sleep(time.Second) {
+				return
+			}
+			continue
+		}
+		log.Infof("sync order book of %s at update %d", srv.symbol, snapshot.LastUpdateID)
+		srv.bookMutex.Lock()
+		srv.book = newOrderBook(snapshot)
+		srv.bookMutex.Unlock()
+		srv.status = StatusRunning
+		if !srv.applyDepth() {
+			return
+		}
+		srv.status = StatusInitializing
+		if !srv.sleep(time.Second) { // Let the stream run ahead of the next snapshot
+			return
+		}
+	}
+}
+
+// applyDepth applies queued diffs to the book until one does not follow it, it returns false on Stop.
+func (srv *OrderBookService) applyDepth() bool {
+	for {
+		var update *DepthUpdate
+		select {
+		case update = <-srv.updateCh:
+		case <-srv.stopCh:
+			return false
+		}
+		srv.bookMutex.Lock()
+		isNew := update.LastUpdateID > srv.book.lastUpdateID
+		err := srv.book.apply(update)
+		if err != nil {
+			srv.book = nil
+		}
+		srv.bookMutex.Unlock()
+		if err != nil {
+			log.Warnf("resync order book of %s: %s, update %d-%d", srv.symbol, err.Error(), update.FirstUpdateID, update.LastUpdateID)
+			return true
+		}
+		if isNew {
+			srv.publish(update)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// OrderBookManager owns one OrderBookService per symbol.
+type OrderBookManager struct {
+	exchange BookExchange
+	limit    int
+	subOpts  SubscriberOptions
+	services map[string]*OrderBookService
+	mutex    sync.RWMutex
+}
+
+func NewOrderBookManager(exchange BookExchange, limit int, subOpts SubscriberOptions) *OrderBookManager {
+	return &OrderBookManager{
+		exchange: exchange,
+		limit:    limit,
+		subOpts:  subOpts,
+		services: make(map[string]*OrderBookService),
+	}
+}
+
+func (m *OrderBookManager) Add(symbol string) (*OrderBookService, error) {
+	key := strings.ToUpper(symbol)
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.services[key]; exists {
+		return nil, errSymbolExist
+	}
+	srv := NewOrderBookService(key, m.limit, m.exchange, m.subOpts)
+	m.services[key] = srv
+	return srv, nil
+}
+
+func (m *OrderBookManager) Get(symbol string) (*OrderBookService, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	srv, exists := m.services[strings.ToUpper(symbol)]
+	if !exists {
+		return nil, errSymbolNotExist
+	}
+	return srv, nil
+}
+
+func (m *OrderBookManager) Symbols() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	result := make([]string, 0, len(m.services))
+	for symbol := range m.services {
+		result = append(result, symbol)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (m *OrderBookManager) Run() {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for symbol, srv := range m.services {
+		log.Infof("Start order book service of %s", symbol)
+		go srv.Run()
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeBookExchange serves the queued snapshots in turn and streams the diffs sent on updateCh.
+type fakeBookExchange struct {
+	snapshots chan *BookSnapshot
+	updateCh  chan *DepthUpdate
+}
+
+func (ex *fakeBookExchange) DepthSnapshot(ctx context.Context, symbol string, limit int) (*BookSnapshot, error) {
+	return <-ex.snapshots, nil
+}
+
+func (ex *fakeBookExchange) DepthStream(symbol string, handler func(*DepthUpdate), errHandler func(error)) (doneC, stopC chan struct{}, err error) {
+	go func() {
+		for update := range ex.updateCh {
+			handler(update)
+		}
+	}()
+	return make(chan struct{}), make(chan struct{}), nil
+}
+
+func TestOrderBookApply(t *testing.T) {
+	book := newOrderBook(&BookSnapshot{
+		LastUpdateID: 100,
+		Bids:         []PriceLevel{{99, 1}, {98, 2}, {97, 3}},
+		Asks:         []PriceLevel{{101, 1}, {102, 2}},
+	})
+	tests := []struct {
+		update *DepthUpdate
+		err    error
+	}{
+		{&DepthUpdate{FirstUpdateID: 90, LastUpdateID: 100, Bids: []PriceLevel{{99, 5}}}, nil}, // Already in the book
+		{&DepthUpdate{FirstUpdateID: 95, LastUpdateID: 105, Bids: []PriceLevel{{99, 0}, {100, 4}}}, nil},
+		{&DepthUpdate{FirstUpdateID: 106, LastUpdateID: 107, Asks: []PriceLevel{{100.5, 1}}}, nil},
+		{&DepthUpdate{FirstUpdateID: 109, LastUpdateID: 110}, errDepthGap},
+	}
+	for _, test := range tests {
+		if err := book.apply(test.update); err != test.err {
+			t.Errorf("Expected %v applying %d-%d, got %v", test.err, test.update.FirstUpdateID, test.update.LastUpdateID, err)
+		}
+	}
+	top := book.top(2)
+	if top.LastUpdateID != 107 || len(top.Bids) != 2 || len(top.Asks) != 2 {
+		t.Fatalf("Unexpected top %+v", top)
+	}
+	if top.Bids[0] != (PriceLevel{100, 4}) || top.Bids[1] != (PriceLevel{98, 2}) {
+		t.Errorf("Unexpected bids %+v", top.Bids)
+	}
+	if top.Asks[0] != (PriceLevel{100.5, 1}) || top.Asks[1] != (PriceLevel{101, 1}) {
+		t.Errorf("Unexpected asks %+v", top.Asks)
+	}
+}
+
+func TestParseBookLimit(t *testing.T) {
+	tests := []struct {
+		limit    int
+		expected int
+		isValid  bool
+	}{
+		{0, 1000, true},
+		{5, 5, true},
+		{5000, 5000, true},
+		{1001, 0, false},
+		{-1, 0, false},
+	}
+	for _, test := range tests {
+		limit, err := ParseBookLimit(test.limit)
+		if (err == nil) != test.isValid || limit != test.expected {
+			t.Errorf("Expected limit %d to parse as %d (valid %v), got %d %v", test.limit, test.expected, test.isValid, limit, err)
+		}
+	}
+}
+
+func TestOrderBookServiceResync(t *testing.T) {
+	ex := &fakeBookExchange{
+		snapshots: make(chan *BookSnapshot, 2),
+		updateCh:  make(chan *DepthUpdate),
+	}
+	ex.snapshots <- &BookSnapshot{LastUpdateID: 10, Bids: []PriceLevel{{99, 1}}, Asks: []PriceLevel{{101, 1}}}
+	ex.snapshots <- &BookSnapshot{LastUpdateID: 20, Bids: []PriceLevel{{95, 1}}, Asks: []PriceLevel{{105, 1}}}
+	srv := NewOrderBookService("BTCUSDT", 1000, ex, SubscriberOptions{})
+	bookCh := make(chan *BookSnapshot, 10)
+	id := srv.Subscribe(5, 0, func(book *BookSnapshot) {
+		bookCh <- book
+	})
+	defer srv.Unsubscribe(id)
+	srv.Run()
+
+	expectBook := func(lastUpdateID int64, bestBid float64) {
+		t.Helper()
+		select {
+		case book := <-bookCh:
+			if book.LastUpdateID != lastUpdateID || book.Bids[0].Price != bestBid {
+				t.Errorf("Expected book at %d with best bid %v, got %+v", lastUpdateID, bestBid, book)
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatalf("Timeout waiting for book at %d", lastUpdateID)
+		}
+	}
+	ex.updateCh <- &DepthUpdate{FirstUpdateID: 5, LastUpdateID: 11, Bids: []PriceLevel{{100, 2}}}
+	expectBook(11, 100)
+	// 12 to 14 are missing, the book is fetched again at 20 and the diffs after it apply
+	ex.updateCh <- &DepthUpdate{FirstUpdateID: 15, LastUpdateID: 16}
+	ex.updateCh <- &DepthUpdate{FirstUpdateID: 17, LastUpdateID: 20, Bids: []PriceLevel{{100, 2}}}
+	ex.updateCh <- &DepthUpdate{FirstUpdateID: 21, LastUpdateID: 22, Bids: []PriceLevel{{96, 1}}}
+	expectBook(22, 96)
+}
+
+func TestOrderBookServiceThrottle(t *testing.T) {
+	srv := NewOrderBookService("BTCUSDT", 1000, &fakeBookExchange{}, SubscriberOptions{})
+	srv.book = newOrderBook(&BookSnapshot{LastUpdateID: 1})
+	bookCh := make(chan *BookSnapshot, 10)
+	id := srv.Subscribe(5, 200*time.Millisecond, func(book *BookSnapshot) {
+		bookCh <- book
+	})
+	defer srv.Unsubscribe(id)
+	for updateID := int64(2); updateID <= 5; updateID++ {
+		update := &DepthUpdate{FirstUpdateID: updateID, LastUpdateID: updateID}
+		srv.bookMutex.Lock()
+		srv.book.apply(update)
+		srv.bookMutex.Unlock()
+		srv.publish(update)
+	}
+	// The first change goes out at once, the rest together once the throttle expires
+	for _, expected := range []int64{2, 5} {
+		select {
+		case book := <-bookCh:
+			if book.LastUpdateID < expected {
+				t.Errorf("Expected book at %d, got %d", expected, book.LastUpdateID)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timeout waiting for book at %d", expected)
+		}
+	}
+	select {
+	case book := <-bookCh:
+		t.Errorf("Expected 2 books, got another at %d", book.LastUpdateID)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
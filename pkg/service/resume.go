@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrResumeTooOld is returned by SubscribeFrom when the first bar asked for is no longer in the container.
+var ErrResumeTooOld = errors.New("resume point is older than the container")
+
+// resumeGate holds back live klines of a subscriber while its replay is running
+// and drops everything already delivered, so replay and live join without gaps or duplicates.
+type resumeGate struct {
+	mutex     sync.Mutex
+	replaying bool
+	pending   []Kline
+	lastSent  int64
+	handler   func(*Kline)
+	sub       *subscriber[Kline]
+}
+
+// live delivers a kline unless the replay is still running, a replay falling behind by more than
+// the subscriber queue holds is a slow consumer and gets the subscriber kicked.
+func (gate *resumeGate) live(kline *Kline) {
+	gate.mutex.Lock()
+	defer gate.mutex.Unlock()
+	if gate.replaying {
+		if len(gate.pending) >= cap(gate.sub.queue) {
+			gate.sub.kick(errSlowConsumer)
+			return
+		}
+		gate.pending = append(gate.pending, *kline)
+		return
+	}
+	gate.send(kline)
+}
+
+func (gate *resumeGate) send(kline *Kline) {
+	if kline.OpenTime <= gate.lastSent {
+		return
+	}
+	gate.lastSent = kline.OpenTime
+	gate.handler(kline)
+}
+
+// SubscribeFrom replays closed klines from the container starting at from and then
+// switches to live delivery, klines are rolled up into the given interval.
+// A from before the first bar fully held in the container fails with ErrResumeTooOld.
+func (srv *KLineService) SubscribeFrom(from int64, interval string, handler func(event *Kline)) (int64, error) {
+	return srv.subscribeFrom(from, interval, false, handler)
+}
+
+// SubscribeSince is SubscribeFrom for callers only warming up on the history,
+// a from before the container starts at its first full bar instead.
+func (srv *KLineService) SubscribeSince(from int64, interval string, handler func(event *Kline)) (int64, error) {
+	return srv.subscribeFrom(from, interval, true, handler)
+}
+
+// FirstOpenTime returns the open time of the first bar of interval fully held in the container.
+func (srv *KLineService) FirstOpenTime(interval string) (int64, error) {
+	agg, err := NewKlineAggregator(interval)
+	if err != nil {
+		return 0, err
+	}
+	head, err := srv.Head()
+	if err != nil {
+		return 0, err
+	}
+	return agg.bucketStart(head.OpenTime-1) + agg.width, nil
+}
+
+func (srv *KLineService) subscribeFrom(from int64, interval string, clamp bool, handler func(event *Kline)) (int64, error) {
+	agg, err := NewKlineAggregator(interval)
+	if err != nil {
+		return 0, err
+	}
+	from = agg.bucketStart(from)
+	first, err := srv.FirstOpenTime(interval)
+	if err != nil {
+		return 0, err
+	}
+	if from < first {
+		if !clamp {
+			return 0, fmt.Errorf("%w: first bar opens at %d", ErrResumeTooOld, first)
+		}
+		from = first
+	}
+	gate := &resumeGate{
+		replaying: true,
+		lastSent:  from - 1,
+		handler:   handler,
+	}
+	if agg.width != intervalMs[baseInterval] {
+		gate.handler = func(kline *Kline) {
+			for _, bar := range agg.Add(kline) {
+				handler(bar)
+			}
+		}
+	}
+	sub := newSubscriber(gate.live, srv.statefulOptions())
+	gate.sub = sub
+	srv.addSubscriber(sub, false)
+	go srv.replay(sub, from, gate)
+	return sub.id, nil
+}
+
+// replay walks the container like publishKline does and stops where the publisher would stop,
+// it runs on its own goroutine so a slow subscriber never holds up the publisher.
+func (srv *KLineService) replay(sub *subscriber[Kline], from int64, gate *resumeGate) {
+	step := intervalMs[baseInterval]
+	key, err := srv.firstKeyFrom(from)
+	for err == nil {
+		kline, getErr := srv.container.Get(key)
+		if getErr != nil {
+			break
+		}
+		nextKey, nextErr := srv.container.Next(key)
+		if nextErr != nil && !kline.IsFinal {
+			break
+		}
+		if nextErr == nil && nextKey != key+step && srv.hasGap(key+step) {
+			break
+		}
+		if sub.isClosed() {
+			return
+		}
+		gate.send(&kline)
+		key, err = nextKey, nextErr
+	}
+
+	gate.mutex.Lock()
+	defer gate.mutex.Unlock()
+	if sub.isClosed() {
+		return
+	}
+	log.Infof("subscriber %d replayed up to %d, %d live klines pending", sub.id, gate.lastSent, len(gate.pending))
+	for i := range gate.pending {
+		gate.send(&gate.pending[i])
+	}
+	gate.pending = nil
+	gate.replaying = false
+}
+
+func (srv *KLineService) firstKeyFrom(from int64) (int64, error) {
+	head, err := srv.Head()
+	if err != nil {
+		return 0, err
+	}
+	if from <= head.OpenTime {
+		return head.OpenTime, nil
+	}
+	tail, err := srv.Tail()
+	if err != nil {
+		return 0, err
+	}
+	for key := from; key <= tail.OpenTime; key += intervalMs[baseInterval] {
+		if _, err := srv.container.Get(key); err == nil {
+			return key, nil
+		}
+	}
+	return 0, errKlineNotExist
+}
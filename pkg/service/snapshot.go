@@ -0,0 +1,289 @@
+package service
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var errSnapshotCorrupted = errors.New("snapshot is corrupted")
+
+// Snapshot and write-ahead log files start with snapshotMagic and snapshotVersion, followed by
+// klineRecordSize byte records written by encodeKline. Bump the version whenever the record changes.
+const (
+	snapshotMagic   = "CFKS"
+	snapshotVersion = uint32(1)
+	klineRecordSize = 11*8 + 1
+)
+
+// snapshotStore keeps the container of a KLineService on local disk, a snapshot file holds
+// the whole window and a write-ahead log holds every closed kline published since then.
+type snapshotStore struct {
+	snapshotPath string
+	walPath      string
+	interval     time.Duration
+	walMutex     sync.Mutex
+	wal          *os.File
+	walWriter    *bufio.Writer
+}
+
+func newSnapshotStore(dir, symbol string, interval time.Duration) (*snapshotStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	name := strings.ToLower(symbol)
+	return &snapshotStore{
+		snapshotPath: filepath.Join(dir, name+".snapshot"),
+		walPath:      filepath.Join(dir, name+".wal"),
+		interval:     interval,
+	}, nil
+}
+
+// EnableSnapshot persists the container under dir every interval and restores it on Run,
+// it has to be called before Run.
+func (srv *KLineService) EnableSnapshot(dir string, interval time.Duration) error {
+	store, err := newSnapshotStore(dir, srv.symbol, interval)
+	if err != nil {
+		return err
+	}
+	srv.store = store
+	return nil
+}
+
+// restoreKline loads the snapshot and the write-ahead logs, dropping klines outside the window.
+func (srv *KLineService) restoreKline() {
+	oldest := time.Now().UnixMilli() - srv.length*intervalMs[baseInterval]
+	nRestored := 0
+	restore := func(kline *Kline) {
+		if kline.OpenTime < oldest || !kline.IsFinal {
+			return
+		}
+		if err := srv.appendKline(kline); err == nil {
+			nRestored++
+		}
+	}
+	for _, path := range []string{srv.store.snapshotPath, srv.store.walPath + ".old", srv.store.walPath} {
+		if err := readKlineFile(path, restore); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Warnf("fail to restore %s: %s", path, err.Error())
+		}
+	}
+	log.Infof("%s restored %d klines from %s", srv.symbol, nRestored, srv.store.snapshotPath)
+	if tail, err := srv.container.Tail(); err == nil {
+		// Restored klines are not published again, the publisher starts after them
+		srv.currentTime = tail.OpenTime
+		srv.isPublished = true
+		srv.restored = true
+	}
+}
+
+func (srv *KLineService) snapshotKline() {
+	if err := srv.store.openWal(); err != nil {
+		log.Errorf("fail to open wal %s", err.Error())
+	}
+	ticker := time.NewTicker(srv.store.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-srv.stopCh:
+			return
+		}
+		if err := srv.store.rotateWal(); err != nil {
+			log.Errorf("fail to rotate wal %s", err.Error())
+			continue
+		}
+		if err := srv.writeSnapshot(); err != nil {
+			log.Errorf("fail to write snapshot %s", err.Error())
+			continue
+		}
+		// Everything in the rotated log is covered by the new snapshot
+		os.Remove(srv.store.walPath + ".old")
+	}
+}
+
+func (srv *KLineService) writeSnapshot() error {
+	tmpPath := srv.store.snapshotPath + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath)
+	writer := bufio.NewWriter(file)
+	writeKlineHeader(writer)
+	record := make([]byte, klineRecordSize)
+	key, err := srv.container.HeadKey(0)
+	for err == nil {
+		kline, getErr := srv.container.Get(key)
+		if getErr != nil {
+			file.Close()
+			return getErr // The kline was popped while walking the container
+		}
+		if !kline.IsFinal {
+			break
+		}
+		encodeKline(record, &kline)
+		if _, err := writer.Write(record); err != nil {
+			file.Close()
+			return err
+		}
+		key, err = srv.container.Next(key)
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	// The rotated log is removed once this returns, so the snapshot has to be on disk first
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, srv.store.snapshotPath); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(srv.store.snapshotPath))
+}
+
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return file.Sync()
+}
+
+func (store *snapshotStore) openWal() error {
+	store.walMutex.Lock()
+	defer store.walMutex.Unlock()
+	file, err := os.OpenFile(store.walPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if info, err := file.Stat(); err == nil && info.Size() == 0 {
+		if err := writeKlineHeader(file); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	store.wal = file
+	store.walWriter = bufio.NewWriter(file)
+	return nil
+}
+
+func (store *snapshotStore) rotateWal() error {
+	store.walMutex.Lock()
+	if store.wal != nil {
+		store.walWriter.Flush()
+		store.wal.Sync()
+		store.wal.Close()
+		store.wal = nil
+	}
+	err := os.Rename(store.walPath, store.walPath+".old")
+	store.walMutex.Unlock()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return store.openWal()
+}
+
+func (store *snapshotStore) appendWal(kline *Kline) {
+	store.walMutex.Lock()
+	defer store.walMutex.Unlock()
+	if store.wal == nil {
+		return
+	}
+	record := make([]byte, klineRecordSize)
+	encodeKline(record, kline)
+	if _, err := store.walWriter.Write(record); err != nil {
+		log.Errorf("fail to append wal %s", err.Error())
+		return
+	}
+	store.walWriter.Flush()
+}
+
+func writeKlineHeader(w io.Writer) error {
+	if _, err := io.WriteString(w, snapshotMagic); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, snapshotVersion)
+}
+
+// readKlineFile reads a snapshot or write-ahead log, a truncated last record is ignored.
+func readKlineFile(path string, handler func(*Kline)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	magic := make([]byte, len(snapshotMagic))
+	var version uint32
+	if _, err := io.ReadFull(reader, magic); err != nil {
+		return errSnapshotCorrupted
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &version); err != nil || string(magic) != snapshotMagic || version != snapshotVersion {
+		return errSnapshotCorrupted
+	}
+	record := make([]byte, klineRecordSize)
+	for {
+		_, err := io.ReadFull(reader, record)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		kline := decodeKline(record)
+		handler(&kline)
+	}
+}
+
+// encodeKline writes the fields of a kline little endian in declaration order, IsFinal as one byte.
+func encodeKline(record []byte, kline *Kline) {
+	le := binary.LittleEndian
+	le.PutUint64(record[0:], uint64(kline.OpenTime))
+	le.PutUint64(record[8:], math.Float64bits(kline.Open))
+	le.PutUint64(record[16:], math.Float64bits(kline.High))
+	le.PutUint64(record[24:], math.Float64bits(kline.Low))
+	le.PutUint64(record[32:], math.Float64bits(kline.Close))
+	le.PutUint64(record[40:], math.Float64bits(kline.Volume))
+	le.PutUint64(record[48:], uint64(kline.CloseTime))
+	le.PutUint64(record[56:], math.Float64bits(kline.QuoteAssetVolume))
+	le.PutUint64(record[64:], uint64(kline.TradeNum))
+	le.PutUint64(record[72:], math.Float64bits(kline.TakerBuyBaseAssetVolume))
+	le.PutUint64(record[80:], math.Float64bits(kline.TakerBuyQuoteAssetVolume))
+	record[88] = 0
+	if kline.IsFinal {
+		record[88] = 1
+	}
+}
+
+func decodeKline(record []byte) Kline {
+	le := binary.LittleEndian
+	return Kline{
+		OpenTime:                 int64(le.Uint64(record[0:])),
+		Open:                     math.Float64frombits(le.Uint64(record[8:])),
+		High:                     math.Float64frombits(le.Uint64(record[16:])),
+		Low:                      math.Float64frombits(le.Uint64(record[24:])),
+		Close:                    math.Float64frombits(le.Uint64(record[32:])),
+		Volume:                   math.Float64frombits(le.Uint64(record[40:])),
+		CloseTime:                int64(le.Uint64(record[48:])),
+		QuoteAssetVolume:         math.Float64frombits(le.Uint64(record[56:])),
+		TradeNum:                 int64(le.Uint64(record[64:])),
+		TakerBuyBaseAssetVolume:  math.Float64frombits(le.Uint64(record[72:])),
+		TakerBuyQuoteAssetVolume: math.Float64frombits(le.Uint64(record[80:])),
+		IsFinal:                  record[88] != 0,
+	}
+}
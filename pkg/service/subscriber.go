@@ -0,0 +1,327 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	errSlowConsumer       = errors.New("subscriber queue is full")
+	errSubscriberNotExist = errors.New("subscriber is not existed")
+	errOverflowNotSupport = errors.New("overflow policy is not supported")
+)
+
+var ErrDisconnected = errors.New("subscriber is disconnected by admin")
+
+type OverflowPolicy string
+
+var (
+	OverflowDropOldest = OverflowPolicy("drop_oldest")
+	OverflowDropNewest = OverflowPolicy("drop_newest")
+	OverflowDisconnect = OverflowPolicy("disconnect")
+)
+
+const defaultQueueSize = 1024
+
+func ParseOverflowPolicy(s string) (OverflowPolicy, error) {
+	switch policy := OverflowPolicy(s); policy {
+	case "":
+		return OverflowDropOldest, nil
+	case OverflowDropOldest, OverflowDropNewest, OverflowDisconnect:
+		return policy, nil
+	default:
+		return "", errOverflowNotSupport
+	}
+}
+
+type SubscriberOptions struct {
+	QueueSize int
+	Overflow  OverflowPolicy
+}
+
+type SubscriberMeta struct {
+	Peer string
+	Name string
+}
+
+type SubscriberInfo struct {
+	ID           int64
+	Peer         string
+	Name         string
+	ConnectTime  int64
+	Sent         int64
+	LastSendTime int64
+	QueueDepth   int64
+	Dropped      int64
+}
+
+// subscriber is a registered handler of a service, items are queued by the publisher and handed
+// to the handler by the subscriber's own goroutine.
+type subscriber[T any] struct {
+	id        int64
+	handler   func(*T)
+	queue     chan T
+	overflow  OverflowPolicy
+	dropped   atomic.Int64
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	errCh     chan error
+	// Monitoring
+	meta         atomic.Pointer[SubscriberMeta]
+	connectTime  int64
+	sent         atomic.Int64
+	lastSendTime atomic.Int64
+}
+
+func newSubscriber[T any](handler func(*T), opts SubscriberOptions) *subscriber[T] {
+	queueSize := opts.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	overflow := opts.Overflow
+	if overflow == "" {
+		overflow = OverflowDropOldest
+	}
+	return &subscriber[T]{
+		handler:     handler,
+		queue:       make(chan T, queueSize),
+		overflow:    overflow,
+		closeCh:     make(chan struct{}),
+		errCh:       make(chan error, 1),
+		connectTime: time.Now().UnixMilli(),
+	}
+}
+
+func (sub *subscriber[T]) run() {
+	for {
+		select {
+		case item := <-sub.queue:
+			sub.handler(&item)
+		case <-sub.closeCh:
+			return
+		}
+	}
+}
+
+// offer enqueues an item without blocking, applying the overflow policy when the queue is full.
+func (sub *subscriber[T]) offer(item *T) {
+	for !sub.isClosed() {
+		select {
+		case sub.queue <- *item:
+			return
+		default:
+		}
+		switch sub.overflow {
+		case OverflowDropNewest:
+			sub.dropped.Add(1)
+			return
+		case OverflowDisconnect:
+			sub.dropped.Add(1)
+			sub.kick(errSlowConsumer)
+			return
+		default:
+			select {
+			case <-sub.queue:
+				sub.dropped.Add(1)
+			default:
+			}
+		}
+	}
+}
+
+// kick closes the subscriber on the service side and reports why to its owner.
+func (sub *subscriber[T]) kick(err error) {
+	sub.closeOnce.Do(func() {
+		sub.errCh <- err
+		close(sub.closeCh)
+	})
+}
+
+func (sub *subscriber[T]) close() {
+	sub.closeOnce.Do(func() {
+		close(sub.closeCh)
+	})
+}
+
+func (sub *subscriber[T]) isClosed() bool {
+	select {
+	case <-sub.closeCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (sub *subscriber[T]) markSent() {
+	sub.sent.Add(1)
+	sub.lastSendTime.Store(time.Now().UnixMilli())
+}
+
+func (sub *subscriber[T]) info() SubscriberInfo {
+	info := SubscriberInfo{
+		ID:           sub.id,
+		ConnectTime:  sub.connectTime,
+		Sent:         sub.sent.Load(),
+		LastSendTime: sub.lastSendTime.Load(),
+		QueueDepth:   int64(len(sub.queue)),
+		Dropped:      sub.dropped.Load(),
+	}
+	if meta := sub.meta.Load(); meta != nil {
+		info.Peer = meta.Peer
+		info.Name = meta.Name
+	}
+	return info
+}
+
+// subscriberRegistry keeps the subscribers of a service publishing one type of event, along with
+// the stop signal of the service's goroutines.
+type subscriberRegistry[T any] struct {
+	stream      string // Names the subscribers in logs, e.g. "trade"
+	symbol      string
+	id          int64
+	subscribers map[int64]*subscriber[T]
+	subOpts     SubscriberOptions
+	subMutex    sync.RWMutex
+	stopCh      chan struct{}
+	stopOnce    sync.Once
+}
+
+func newSubscriberRegistry[T any](stream, symbol string, subOpts SubscriberOptions) *subscriberRegistry[T] {
+	return &subscriberRegistry[T]{
+		stream:      stream,
+		symbol:      symbol,
+		subscribers: make(map[int64]*subscriber[T]),
+		subOpts:     subOpts,
+		stopCh:      make(chan struct{}),
+	}
+}
+
+func (reg *subscriberRegistry[T]) subscribe(handler func(*T)) int64 {
+	sub := newSubscriber(handler, reg.subOpts)
+	reg.subMutex.Lock()
+	defer reg.subMutex.Unlock()
+	sub.id = reg.id
+	reg.subscribers[sub.id] = sub
+	reg.id++
+	go sub.run()
+	return sub.id
+}
+
+// Kicked returns a channel receiving the reason when the service drops the subscriber.
+func (reg *subscriberRegistry[T]) Kicked(subscriberID int64) (<-chan error, error) {
+	sub, err := reg.getSubscriber(subscriberID)
+	if err != nil {
+		return nil, err
+	}
+	return sub.errCh, nil
+}
+
+func (reg *subscriberRegistry[T]) DescribeSubscriber(subscriberID int64, meta SubscriberMeta) error {
+	sub, err := reg.getSubscriber(subscriberID)
+	if err != nil {
+		return err
+	}
+	sub.meta.Store(&meta)
+	return nil
+}
+
+func (reg *subscriberRegistry[T]) MarkSent(subscriberID int64) {
+	if sub, err := reg.getSubscriber(subscriberID); err == nil {
+		sub.markSent()
+	}
+}
+
+// Disconnect forcibly drops a subscriber, its owner is told through Kicked.
+func (reg *subscriberRegistry[T]) Disconnect(subscriberID int64) error {
+	sub, err := reg.getSubscriber(subscriberID)
+	if err != nil {
+		return err
+	}
+	log.Infof("disconnect %s subscriber %d of %s", reg.stream, subscriberID, reg.symbol)
+	sub.kick(ErrDisconnected)
+	return nil
+}
+
+func (reg *subscriberRegistry[T]) SubscriberInfos() []SubscriberInfo {
+	result := []SubscriberInfo{}
+	reg.subMutex.RLock()
+	defer reg.subMutex.RUnlock()
+	for _, sub := range reg.subscribers {
+		result = append(result, sub.info())
+	}
+	return result
+}
+
+func (reg *subscriberRegistry[T]) getSubscriber(subscriberID int64) (*subscriber[T], error) {
+	reg.subMutex.RLock()
+	defer reg.subMutex.RUnlock()
+	sub, exists := reg.subscribers[subscriberID]
+	if !exists {
+		return nil, errSubscriberNotExist
+	}
+	return sub, nil
+}
+
+func (reg *subscriberRegistry[T]) Unsubscribe(subscriberID int64) error {
+	reg.subMutex.Lock()
+	defer reg.subMutex.Unlock()
+	if sub, exists := reg.subscribers[subscriberID]; exists {
+		sub.close()
+	}
+	delete(reg.subscribers, subscriberID)
+	log.Infof("current number of %s subscribers %d", reg.stream, len(reg.subscribers))
+	return nil
+}
+
+func (reg *subscriberRegistry[T]) publish(item *T) {
+	reg.subMutex.RLock()
+	defer reg.subMutex.RUnlock()
+	for _, sub := range reg.subscribers {
+		sub.offer(item)
+	}
+}
+
+// Stop ends the goroutines of the service, subscribers stay until their owners unsubscribe.
+func (reg *subscriberRegistry[T]) Stop() {
+	reg.stopOnce.Do(func() {
+		close(reg.stopCh)
+	})
+}
+
+func (reg *subscriberRegistry[T]) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-reg.stopCh:
+		return false
+	}
+}
+
+// serve keeps the websocket stream opened by dial connected until Stop, dialing again 5 seconds
+// after a failed attempt and right after a dropped connection.
+func (reg *subscriberRegistry[T]) serve(dial func() (doneC, stopC chan struct{}, err error)) {
+	log.Infof("start subscribe %s of %s", reg.stream, reg.symbol)
+	for {
+		doneC, stopC, err := dial()
+		if err != nil {
+			log.Errorf("fail to create ws channel: %s", err.Error())
+			if !reg.sleep(5 * time.Second) {
+				return
+			}
+			continue
+		}
+		select {
+		case <-doneC:
+		case <-reg.stopCh:
+			close(stopC)
+			<-doneC
+			return
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TradeManager owns one TradeService per symbol, all of them ingesting the same kind of trades.
+type TradeManager struct {
+	exchange TradeExchange
+	kind     TradeKind
+	subOpts  SubscriberOptions
+	services map[string]*TradeService
+	mutex    sync.RWMutex
+}
+
+func NewTradeManager(exchange TradeExchange, kind TradeKind, subOpts SubscriberOptions) *TradeManager {
+	return &TradeManager{
+		exchange: exchange,
+		kind:     kind,
+		subOpts:  subOpts,
+		services: make(map[string]*TradeService),
+	}
+}
+
+func (m *TradeManager) Add(symbol string, length int64) (*TradeService, error) {
+	key := strings.ToUpper(symbol)
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.services[key]; exists {
+		return nil, errSymbolExist
+	}
+	srv := NewTradeService(key, m.kind, length, m.exchange, m.subOpts)
+	m.services[key] = srv
+	return srv, nil
+}
+
+func (m *TradeManager) Get(symbol string) (*TradeService, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	srv, exists := m.services[strings.ToUpper(symbol)]
+	if !exists {
+		return nil, errSymbolNotExist
+	}
+	return srv, nil
+}
+
+func (m *TradeManager) Symbols() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	result := make([]string, 0, len(m.services))
+	for symbol := range m.services {
+		result = append(result, symbol)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (m *TradeManager) Run() {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for symbol, srv := range m.services {
+		log.Infof("Start trade service of %s", symbol)
+		go srv.Run()
+	}
+}
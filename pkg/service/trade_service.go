@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/BullionBear/crypto-feed/pkg/linkedlist"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	errTradeOutOfOrder     = errors.New("trade is out of order")
+	errTradeKindNotSupport = errors.New("trade kind is not supported")
+)
+
+type TradeKind string
+
+var (
+	AggTrade = TradeKind("agg_trade") // Fills of one taker order at one price, the aggTrade stream
+	RawTrade = TradeKind("trade")     // Every individual fill, the trade stream
+)
+
+func ParseTradeKind(s string) (TradeKind, error) {
+	switch kind := TradeKind(s); kind {
+	case "":
+		return AggTrade, nil
+	case AggTrade, RawTrade:
+		return kind, nil
+	default:
+		return "", errTradeKindNotSupport
+	}
+}
+
+const tradePageLimit = 1000
+
+// TradeService keeps the latest length trades of a symbol keyed by trade id. Ids the websocket missed,
+// e.g. while reconnecting, are fetched over REST before the next live trade, so subscribers get every id in order.
+type TradeService struct {
+	symbol string
+	kind   TradeKind
+	length int64
+	// Container
+	container linkedlist.IndexLinkedList[Trade]
+	// Dependencies
+	exchange TradeExchange
+	// Subscriber
+	*subscriberRegistry[Trade]
+	// Dynamic varaible
+	status Status
+	errCh  chan struct{}
+	// pipeline control
+	pushMutex sync.Mutex
+}
+
+func NewTradeService(symbol string, kind TradeKind, length int64, exchange TradeExchange, subOpts SubscriberOptions) *TradeService {
+	return &TradeService{
+		symbol:             symbol,
+		kind:               kind,
+		length:             length,
+		container:          *linkedlist.NewIndexedLinkedList[Trade](),
+		exchange:           exchange,
+		subscriberRegistry: newSubscriberRegistry[Trade]("trade", symbol, subOpts),
+		status:             StatusCreated,
+		errCh:              make(chan struct{}),
+	}
+}
+
+func (srv *TradeService) Run() error {
+	srv.status = StatusInitializing
+	srv.requestRecentTrade()
+	log.Infof("Received recent trades of %s", srv.symbol)
+	srv.requestHistoricalTrade()
+	log.Infof("Finish retrieve historical trades of %s", srv.symbol)
+	go srv.subscribeCurrentTrade()
+	srv.status = StatusRunning
+	go func() {
+		for {
+			select {
+			case <-srv.errCh:
+				srv.status = StatusError
+				log.Errorf("some goroutine dead, need to check")
+			case <-srv.stopCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (srv *TradeService) Symbol() string {
+	return srv.symbol
+}
+
+func (srv *TradeService) Kind() TradeKind {
+	return srv.kind
+}
+
+func (srv *TradeService) Length() int64 {
+	return srv.length
+}
+
+func (srv *TradeService) Head() (Trade, error) {
+	return srv.container.Head()
+}
+
+func (srv *TradeService) Tail() (Trade, error) {
+	return srv.container.Tail()
+}
+
+func (srv *TradeService) Size() int64 {
+	return srv.container.Size()
+}
+
+func (srv *TradeService) Status() Status {
+	return srv.status
+}
+
+func (srv *TradeService) Subscribe(handler func(event *Trade)) int64 {
+	return srv.subscribe(handler)
+}
+
+// Query passes the trades executed within [start, end] in id order. A non-zero fromID starts
+// the walk at that trade instead of the head, so a client can resume from the last id it got.
+// An error of the handler stops the walk and is returned.
+func (srv *TradeService) Query(start int64, end int64, fromID int64, handler func(event *Trade) error) error {
+	tail, err := srv.container.Tail()
+	if err != nil {
+		return err
+	}
+	key := fromID
+	if key == 0 {
+		head, err := srv.container.Head()
+		if err != nil {
+			return err
+		}
+		key = head.ID
+	}
+	for {
+		trade, err := srv.container.Get(key)
+		if err != nil {
+			log.Errorf("Failed to query trade %d: %s", key, err.Error())
+			return err
+		}
+		if trade.Time > end {
+			return nil
+		}
+		if trade.Time >= start {
+			if err := handler(&trade); err != nil {
+				return err
+			}
+		}
+		if key == tail.ID {
+			return nil
+		}
+		if key, err = srv.container.Next(key); err != nil {
+			log.Errorf("Failed to get next trade after %d: %s", key, err.Error())
+			return err
+		}
+	}
+}
+
+func (srv *TradeService) subscribeCurrentTrade() {
+	var wsTradeHandler = func(trade *Trade) {
+		srv.pushBack(trade)
+	}
+	var errHandler = func(err error) {
+		log.Errorf("handle error of wsTrade %s", err.Error())
+	}
+	srv.serve(func() (doneC, stopC chan struct{}, err error) {
+		return srv.exchange.TradeStream(srv.symbol, srv.kind, wsTradeHandler, errHandler)
+	})
+}
+
+// requestRecentTrade seeds the container with the latest trades, retrying until the exchange answers or Stop.
+func (srv *TradeService) requestRecentTrade() {
+	for {
+		trades, err := srv.exchange.RecentTrades(context.Background(), srv.symbol, srv.kind, tradePageLimit)
+		if err != nil {
+			log.Errorf("Fail to retrieve recent trades %s", err.Error())
+			if !srv.sleep(time.Second) {
+				return
+			}
+			continue
+		}
+		for _, trade := range trades {
+			if err := srv.pushBack(trade); err != nil {
+				log.Errorf("Fail to push back trade %+v", trade)
+			}
+		}
+		return
+	}
+}
+
+// requestHistoricalTrade pages backwards from the head until the container holds length trades.
+func (srv *TradeService) requestHistoricalTrade() {
+	for size := srv.container.Size(); size < srv.length; size = srv.container.Size() {
+		head, err := srv.container.Head()
+		if err != nil || head.ID == 0 {
+			return // Nothing traded yet or nothing older
+		}
+		fromID := head.ID - min(int64(tradePageLimit), srv.length-size)
+		if fromID < 0 {
+			fromID = 0
+		}
+		trades, err := srv.exchange.TradePage(context.Background(), srv.symbol, srv.kind, fromID, int(head.ID-fromID))
+		if err != nil {
+			log.Errorf("Fail to retrieve historical trades %s", err.Error())
+			if !srv.sleep(time.Second) {
+				return
+			}
+			continue
+		}
+		if len(trades) == 0 {
+			return
+		}
+		for i := len(trades) - 1; i >= 0; i-- {
+			trade := trades[i]
+			if err := srv.pushFront(trade); err != nil {
+				log.Errorf("Fail to push front trade %+v", trade)
+			}
+		}
+		time.Sleep(30 * time.Millisecond) // avoid reach request rate limit
+	}
+}
+
+// pushBack appends and publishes a trade, the ids between the tail and the trade are fetched first.
+func (srv *TradeService) pushBack(trade *Trade) error {
+	srv.pushMutex.Lock()
+	defer srv.pushMutex.Unlock()
+	if tail, err := srv.container.Tail(); err == nil {
+		if trade.ID <= tail.ID {
+			return errTradeOutOfOrder
+		}
+		if trade.ID > tail.ID+1 {
+			srv.fillTrade(tail.ID+1, trade.ID)
+		}
+	}
+	return srv.appendTrade(trade)
+}
+
+// fillTrade appends the trades with an id in [from, to), at most the latest length of them.
+// A failed request leaves the rest of the gap open rather than holding up the live stream.
+func (srv *TradeService) fillTrade(from int64, to int64) {
+	if to-from > srv.length {
+		from = to - srv.length
+	}
+	log.Infof("backfill trades %d to %d of %s", from, to-1, srv.symbol)
+	for from < to {
+		trades, err := srv.exchange.TradePage(context.Background(), srv.symbol, srv.kind, from, int(min(int64(tradePageLimit), to-from)))
+		if err != nil {
+			log.Errorf("Fail to backfill trades from %d: %s", from, err.Error())
+			return
+		}
+		if len(trades) == 0 {
+			return
+		}
+		for _, trade := range trades {
+			if trade.ID >= to {
+				return
+			}
+			if err := srv.appendTrade(trade); err != nil {
+				log.Errorf("Fail to append trade %+v", trade)
+			}
+		}
+		from = trades[len(trades)-1].ID + 1
+		time.Sleep(30 * time.Millisecond) // avoid reach request rate limit
+	}
+}
+
+// appendTrade stores a trade behind the tail, drops the oldest beyond length and publishes it.
+// The caller holds pushMutex.
+func (srv *TradeService) appendTrade(trade *Trade) error {
+	if tail, err := srv.container.Tail(); err == nil && trade.ID <= tail.ID {
+		return errTradeOutOfOrder
+	}
+	if err := srv.container.PushBack(trade.ID, *trade); err != nil {
+		return err
+	}
+	for srv.container.Size() > srv.length {
+		if _, err := srv.container.PopFront(); err != nil {
+			log.Errorf("fail to pop trade %s", err.Error())
+			break
+		}
+	}
+	srv.publish(trade)
+	return nil
+}
+
+func (srv *TradeService) pushFront(trade *Trade) error {
+	srv.pushMutex.Lock()
+	defer srv.pushMutex.Unlock()
+	if head, err := srv.container.Head(); err == nil && trade.ID >= head.ID {
+		return errTradeOutOfOrder
+	}
+	return srv.container.PushFront(trade.ID, *trade)
+}
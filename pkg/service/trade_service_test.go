@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeTradeExchange serves trades with ids up to lastID, trade i executes at i*10 ms.
+// The stop channel of every stream opened is passed on streams, if set.
+type fakeTradeExchange struct {
+	lastID  int64
+	streams chan chan struct{}
+}
+
+func newFakeTrade(id int64) *Trade {
+	return &Trade{ID: id, Price: float64(100 + id%7), Quantity: 1, FirstTradeID: id, LastTradeID: id, Time: id * 10}
+}
+
+func (ex *fakeTradeExchange) TradePage(ctx context.Context, symbol string, kind TradeKind, fromID int64, limit int) ([]*Trade, error) {
+	trades := []*Trade{}
+	for id := fromID; id <= ex.lastID && len(trades) < limit; id++ {
+		trades = append(trades, newFakeTrade(id))
+	}
+	return trades, nil
+}
+
+func (ex *fakeTradeExchange) RecentTrades(ctx context.Context, symbol string, kind TradeKind, limit int) ([]*Trade, error) {
+	return ex.TradePage(ctx, symbol, kind, ex.lastID-int64(limit)+1, limit)
+}
+
+func (ex *fakeTradeExchange) TradeStream(symbol string, kind TradeKind, handler func(*Trade), errHandler func(error)) (doneC, stopC chan struct{}, err error) {
+	doneC, stopC = make(chan struct{}), make(chan struct{})
+	go func() {
+		<-stopC
+		close(doneC)
+	}()
+	if ex.streams != nil {
+		ex.streams <- stopC
+	}
+	return doneC, stopC, nil
+}
+
+func TestTradeServiceBackfill(t *testing.T) {
+	srv := NewTradeService("BTCUSDT", AggTrade, 2500, &fakeTradeExchange{lastID: 5000}, SubscriberOptions{})
+	if err := srv.Run(); err != nil {
+		t.Fatalf("Error running service: %v", err)
+	}
+	head, _ := srv.Head()
+	tail, _ := srv.Tail()
+	if srv.Size() != 2500 || head.ID != 2501 || tail.ID != 5000 {
+		t.Fatalf("Expected trades 2501 to 5000, got %d trades from %d to %d", srv.Size(), head.ID, tail.ID)
+	}
+	expected := head.ID
+	err := srv.Query(0, tail.Time, 0, func(trade *Trade) error {
+		if trade.ID != expected {
+			t.Errorf("Expected trade %d, got %d", expected, trade.ID)
+		}
+		expected++
+		return nil
+	})
+	if err != nil || expected != 5001 {
+		t.Errorf("Expected to query up to 5000, got %d %v", expected-1, err)
+	}
+}
+
+func TestTradeServiceFillGap(t *testing.T) {
+	srv := NewTradeService("BTCUSDT", AggTrade, 100, &fakeTradeExchange{lastID: 2000}, SubscriberOptions{})
+	srv.pushBack(newFakeTrade(10))
+	tradeCh := make(chan int64, 10)
+	id := srv.Subscribe(func(trade *Trade) {
+		tradeCh <- trade.ID
+	})
+	defer srv.Unsubscribe(id)
+
+	// The ids missed in between are fetched and published first
+	if err := srv.pushBack(newFakeTrade(15)); err != nil {
+		t.Fatalf("Error pushing trade: %v", err)
+	}
+	for expected := int64(11); expected <= 15; expected++ {
+		select {
+		case got := <-tradeCh:
+			if got != expected {
+				t.Fatalf("Expected trade %d, got %d", expected, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timeout waiting for trade %d", expected)
+		}
+	}
+	if err := srv.pushBack(newFakeTrade(12)); err != errTradeOutOfOrder {
+		t.Errorf("Expected errTradeOutOfOrder, got %v", err)
+	}
+
+	// A gap wider than the window only fetches the latest length trades
+	srv.pushBack(newFakeTrade(1000))
+	head, _ := srv.Head()
+	if srv.Size() != 100 || head.ID != 901 {
+		t.Errorf("Expected 100 trades from 901, got %d from %d", srv.Size(), head.ID)
+	}
+}
+
+func TestTradeServiceQuery(t *testing.T) {
+	srv := NewTradeService("BTCUSDT", AggTrade, 100, &fakeTradeExchange{}, SubscriberOptions{})
+	for id := int64(1); id <= 50; id++ {
+		srv.pushBack(newFakeTrade(id))
+	}
+	tests := []struct {
+		start, end, fromID int64
+		first, last        int64
+	}{
+		{105, 200, 0, 11, 20},
+		{0, 1000, 40, 40, 50},
+		{0, 300, 25, 25, 30},
+	}
+	for _, test := range tests {
+		ids := []int64{}
+		err := srv.Query(test.start, test.end, test.fromID, func(trade *Trade) error {
+			ids = append(ids, trade.ID)
+			return nil
+		})
+		if err != nil || len(ids) != int(test.last-test.first+1) || ids[0] != test.first || ids[len(ids)-1] != test.last {
+			t.Errorf("Expected trades %d to %d for %+v, got %v %v", test.first, test.last, test, ids, err)
+		}
+	}
+	if err := srv.Query(0, 1000, 99, func(*Trade) error { return nil }); err == nil {
+		t.Errorf("Expected an error resuming from a trade not in the window")
+	}
+	// A failing handler stops the walk
+	errStop := errors.New("stop")
+	n := 0
+	if err := srv.Query(0, 1000, 0, func(*Trade) error { n++; return errStop }); err != errStop || n != 1 {
+		t.Errorf("Expected to stop at the first trade, got %d trades %v", n, err)
+	}
+}
+
+func TestTradeServiceDisconnectSubscriber(t *testing.T) {
+	srv := NewTradeService("BTCUSDT", AggTrade, 100, &fakeTradeExchange{}, SubscriberOptions{})
+	id := srv.Subscribe(func(trade *Trade) {})
+	srv.DescribeSubscriber(id, SubscriberMeta{Name: "tape"})
+	infos := srv.SubscriberInfos()
+	if len(infos) != 1 || infos[0].ID != id || infos[0].Name != "tape" {
+		t.Errorf("Unexpected subscriber infos %+v", infos)
+	}
+	kickedCh, _ := srv.Kicked(id)
+	if err := srv.Disconnect(id); err != nil {
+		t.Fatalf("Error disconnecting subscriber: %v", err)
+	}
+	if err := <-kickedCh; err != ErrDisconnected {
+		t.Errorf("Expected ErrDisconnected, got %v", err)
+	}
+	if err := srv.Disconnect(id + 1); err != errSubscriberNotExist {
+		t.Errorf("Expected errSubscriberNotExist, got %v", err)
+	}
+}
+
+func TestTradeServiceStop(t *testing.T) {
+	ex := &fakeTradeExchange{lastID: 5000, streams: make(chan chan struct{}, 2)}
+	srv := NewTradeService("BTCUSDT", AggTrade, 100, ex, SubscriberOptions{})
+	if err := srv.Run(); err != nil {
+		t.Fatalf("Error running service: %v", err)
+	}
+	var stopC chan struct{}
+	select {
+	case stopC = <-ex.streams:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the trade stream to be opened")
+	}
+	srv.Stop()
+	select {
+	case <-stopC:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected Stop to close the trade stream")
+	}
+	select {
+	case <-ex.streams:
+		t.Errorf("Expected no stream to be opened after Stop")
+	case <-time.After(100 * time.Millisecond):
+	}
+}